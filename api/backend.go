@@ -2,7 +2,6 @@ package api
 
 import (
 	"math"
-	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	gethcore "github.com/ethereum/go-ethereum/core"
@@ -11,15 +10,17 @@ import (
 	"github.com/holiman/uint256"
 	"github.com/smartbch/moeingevm/types"
 
+	"github.com/elfinguard/chainlogs/bch"
 	"github.com/elfinguard/chainlogs/chains"
+	"github.com/elfinguard/chainlogs/signer"
+	chainlogstypes "github.com/elfinguard/chainlogs/types"
 )
 
 var _ BackendService = &apiBackend{}
 
 type apiBackend struct {
-	vc         *chains.VirtualChain
-	txFeed     event.Feed
-	rmLogsFeed event.Feed
+	vc     *chains.VirtualChain
+	txFeed event.Feed
 }
 
 func NewBackend(vc *chains.VirtualChain) BackendService {
@@ -28,8 +29,70 @@ func NewBackend(vc *chains.VirtualChain) BackendService {
 	}
 }
 
-func (backend *apiBackend) ChainId() *big.Int {
-	return uint256.NewInt(0).SetBytes32(backend.vc.ChainID[:]).ToBig()
+// ChainId is the EVM chain id derived from ChainIdentity
+func (backend *apiBackend) ChainId() uint64 {
+	return backend.vc.EvmChainId
+}
+
+// ChainIdentity is the 32-byte chain id, which is signed together with the logs
+func (backend *apiBackend) ChainIdentity() common.Hash {
+	return backend.vc.ChainID
+}
+
+func (backend *apiBackend) MainChainEndpoints() []bch.EndpointStatus {
+	return backend.vc.MainChainEndpoints()
+}
+
+func (backend *apiBackend) ScannerHealth() chainlogstypes.ScannerHealth {
+	return backend.vc.ScannerHealth()
+}
+
+func (backend *apiBackend) QuarantinedTxs() []chainlogstypes.QuarantinedTx {
+	return backend.vc.GetQuarantinedTxs()
+}
+
+func (backend *apiBackend) DroppedTxs() []chainlogstypes.DroppedTx {
+	return backend.vc.GetDroppedTxs()
+}
+
+func (backend *apiBackend) SkippedBlocks() []chainlogstypes.SkippedBlock {
+	return backend.vc.GetSkippedBlocks()
+}
+
+func (backend *apiBackend) PrevoutStats() chainlogstypes.PrevoutStats {
+	return backend.vc.PrevoutStats()
+}
+
+func (backend *apiBackend) LogSigner() signer.Signer {
+	return backend.vc.Signer
+}
+
+// Confirmations returns the main chain confirmations of an EGTX, -1 if it is not found by the main chain node
+func (backend *apiBackend) Confirmations(txHash common.Hash) int32 {
+	return backend.vc.GetConfirmations(txidBytes(txHash))
+}
+
+func (backend *apiBackend) LogProof(txHash common.Hash, fromHeight int64, count int) (*chainlogstypes.TxProof, error) {
+	if _, _, err := backend.vc.Store.GetTxByHash(txHash); err != nil {
+		return nil, err
+	}
+	return backend.vc.GetTxProof(txidBytes(txHash), fromHeight, count)
+}
+
+func (backend *apiBackend) SourceTx(txHash common.Hash) (*chainlogstypes.SourceTx, error) {
+	if _, _, err := backend.vc.Store.GetTxByHash(txHash); err != nil {
+		return nil, err
+	}
+	return backend.vc.GetSourceTx(txidBytes(txHash))
+}
+
+func (backend *apiBackend) ScanStatus() (chainlogstypes.ScanStatus, error) {
+	status, err := backend.vc.GetScanStatus()
+	if err != nil {
+		return status, err
+	}
+	status.LatestHeight = backend.LatestHeight()
+	return status, nil
 }
 
 func (backend *apiBackend) BlockByNumber(number int64) (*types.Block, error) {
@@ -69,11 +132,7 @@ func (backend *apiBackend) GetTxListByHeightWithRange(height uint32, start, end
 		for i := 0; i < len(tx.Logs); i++ {
 			l := &tx.Logs[i]
 			if len(l.Data) >= 32 {
-				hash := tx.Hash
-				for i, j := 0, 31; i < j; i, j = i+1, j-1 {
-					hash[i], hash[j] = hash[j], hash[i]
-				}
-				c := backend.vc.GetConfirmations(hash)
+				c := backend.vc.GetConfirmations(txidBytes(tx.Hash))
 				latestConfirmations := uint256.NewInt(uint64(c)).Bytes32()
 				if c == -1 {
 					latestConfirmations = uint256.NewInt(0).SetAllOne().Bytes32()
@@ -97,12 +156,7 @@ func (backend *apiBackend) QueryLogs(addresses []common.Address, topics [][]comm
 	for i := 0; i < len(logs); i++ {
 		l := &logs[i]
 		if len(l.Data) >= 32 {
-			h := l.TxHash
-			// flip txHash, as of bch txHash is reverse of txid
-			for i, j := 0, 31; i < j; i, j = i+1, j-1 {
-				h[i], h[j] = h[j], h[i]
-			}
-			c := backend.vc.GetConfirmations(h)
+			c := backend.vc.GetConfirmations(txidBytes(l.TxHash))
 			latestConfirmations := uint256.NewInt(uint64(c)).Bytes32()
 			if c == -1 {
 				latestConfirmations = uint256.NewInt(0).SetAllOne().Bytes32()
@@ -128,5 +182,14 @@ func (backend *apiBackend) SubscribeNewTxsEvent(ch chan<- gethcore.NewTxsEvent)
 	return backend.txFeed.Subscribe(ch)
 }
 func (backend *apiBackend) SubscribeRemovedLogsEvent(ch chan<- gethcore.RemovedLogsEvent) event.Subscription {
-	return backend.rmLogsFeed.Subscribe(ch)
+	return backend.vc.SubscribeRemovedLogsEvent(ch)
+}
+
+// txidBytes flips txHash, as of bch txHash is reverse of txid
+func txidBytes(txHash common.Hash) [32]byte {
+	h := txHash
+	for i, j := 0, 31; i < j; i, j = i+1, j-1 {
+		h[i], h[j] = h[j], h[i]
+	}
+	return h
 }
@@ -7,7 +7,10 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/smartbch/moeingevm/types"
 	motypes "github.com/smartbch/moeingevm/types"
-	"math/big"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/signer"
+	chainlogstypes "github.com/elfinguard/chainlogs/types"
 )
 
 type CallDetail struct {
@@ -26,16 +29,28 @@ type FilterService interface {
 	SubscribeChainEvent(ch chan<- motypes.ChainEvent) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*gethtypes.Log) event.Subscription
+	GetTxListByHeight(height uint32) (tx []*motypes.Transaction, sigs [][65]byte, err error)
 }
 
 type BackendService interface {
 	FilterService
 	GetTx(txHash common.Hash) (*types.Transaction, [65]byte, error)
-	GetTxListByHeight(height uint32) (tx []*motypes.Transaction, sigs [][65]byte, err error)
 	GetRpcMaxLogResults() int
 	BlockByNumber(number int64) (*motypes.Block, error)
 	BlockByHash(hash common.Hash) (*types.Block, error)
 	LatestHeight() int64
 	QueryLogs(addresses []common.Address, topics [][]common.Hash, startHeight, endHeight uint32, filter types.FilterFunc) ([]types.Log, error)
-	ChainId() *big.Int
+	ChainId() uint64
+	ChainIdentity() common.Hash
+	MainChainEndpoints() []bch.EndpointStatus
+	ScannerHealth() chainlogstypes.ScannerHealth
+	QuarantinedTxs() []chainlogstypes.QuarantinedTx
+	DroppedTxs() []chainlogstypes.DroppedTx
+	SkippedBlocks() []chainlogstypes.SkippedBlock
+	PrevoutStats() chainlogstypes.PrevoutStats
+	LogSigner() signer.Signer
+	LogProof(txHash common.Hash, fromHeight int64, count int) (*chainlogstypes.TxProof, error)
+	Confirmations(txHash common.Hash) int32
+	SourceTx(txHash common.Hash) (*chainlogstypes.SourceTx, error)
+	ScanStatus() (chainlogstypes.ScanStatus, error)
 }
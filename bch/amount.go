@@ -0,0 +1,90 @@
+package bch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/gcash/bchd/btcjson"
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+var satoshiPerCoin = big.NewRat(1e8, 1)
+
+// Amount is an amount in satoshis. It is decoded from the decimal coin amount in the node's JSON
+// without going through float64, whose rounding can make two adaptors disagree by one satoshi.
+type Amount int64
+
+// ParseAmount parses a coin amount with at most 8 decimals, such as "0.29" or "1e-05"
+func ParseAmount(s string) (Amount, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAmount, s)
+	}
+	r.Mul(r, satoshiPerCoin)
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAmount, s)
+	}
+	return Amount(r.Num().Int64()), nil
+}
+
+// String formats the amount in coins with 8 decimals, like the nodes do
+func (a Amount) String() string {
+	sign := ""
+	u := uint64(a)
+	if a < 0 {
+		sign = "-"
+		u = uint64(-a)
+		if a == math.MinInt64 {
+			u = 1 << 63
+		}
+	}
+	return fmt.Sprintf("%s%d.%08d", sign, u/1e8, u%1e8)
+}
+
+// Set makes Amount a flag.Value
+func (a *Amount) Set(s string) (err error) {
+	*a, err = ParseAmount(s)
+	return
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	amount, err := ParseAmount(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+// The results below shadow the float64 amounts of the btcjson results with exact ones
+
+type Vout struct {
+	btcjson.Vout
+	Value Amount `json:"value"`
+}
+
+type TxRawResult struct {
+	btcjson.TxRawResult
+	Vout []Vout `json:"vout"`
+}
+
+type GetBlockVerboseTxResult struct {
+	btcjson.GetBlockVerboseTxResult
+	Tx []TxRawResult `json:"tx,omitempty"`
+}
+
+type GetTxOutResult struct {
+	btcjson.GetTxOutResult
+	Value Amount `json:"value"`
+}
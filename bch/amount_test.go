@@ -0,0 +1,77 @@
+package bch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	for _, c := range []struct {
+		value   string
+		satoshi Amount
+	}{
+		// uint64(value*1e8) is one satoshi less for these values
+		{"0.29", 29_000_000},
+		{"0.57", 57_000_000},
+		{"1.15", 115_000_000},
+		{"4.35", 435_000_000},
+		{"2.675", 267_500_000},
+		{"20999999.99999999", 2_099_999_999_999_999},
+		{"8999999999.99999999", 899_999_999_999_999_999},
+		{"0.00000001", 1},
+		{"1e-05", 1000},
+		{"0", 0},
+		{"-0.5", -50_000_000},
+	} {
+		amount, err := ParseAmount(c.value)
+		require.NoError(t, err, c.value)
+		require.Equal(t, c.satoshi, amount, c.value)
+	}
+	for _, s := range []string{"", "abc", "0.000000001", "100000000000.1"} {
+		_, err := ParseAmount(s)
+		require.ErrorIs(t, err, ErrInvalidAmount, s)
+	}
+
+	require.Equal(t, "0.29000000", Amount(29_000_000).String())
+	require.Equal(t, "-0.00000001", Amount(-1).String())
+}
+
+func TestAmountJSON(t *testing.T) {
+	raw := `{"txid":"01","vin":[],"vout":[{"value":0.29,"n":0,"scriptPubKey":{"type":"pubkeyhash"}},{"value":4.35,"n":1}]}`
+	var tx TxRawResult
+	require.NoError(t, json.Unmarshal([]byte(raw), &tx))
+	require.Equal(t, "01", tx.Txid)
+	require.Len(t, tx.Vout, 2)
+	require.Equal(t, Amount(29_000_000), tx.Vout[0].Value)
+	require.Equal(t, "pubkeyhash", tx.Vout[0].ScriptPubKey.Type)
+	require.Equal(t, Amount(435_000_000), tx.Vout[1].Value)
+	require.EqualValues(t, 1, tx.Vout[1].N)
+
+	bz, err := json.Marshal(tx.Vout[0])
+	require.NoError(t, err)
+	var vout Vout
+	require.NoError(t, json.Unmarshal(bz, &vout))
+	require.Equal(t, tx.Vout[0], vout)
+
+	var txOut *GetTxOutResult
+	require.NoError(t, json.Unmarshal([]byte("null"), &txOut))
+	require.Nil(t, txOut)
+	require.NoError(t, json.Unmarshal([]byte(`{"value":1.15,"confirmations":3}`), &txOut))
+	require.Equal(t, Amount(115_000_000), txOut.Value)
+	require.EqualValues(t, 3, txOut.Confirmations)
+
+	require.Error(t, json.Unmarshal([]byte(`{"value":0.000000001}`), &txOut))
+}
+
+func TestPackAddressAndValue(t *testing.T) {
+	address := [20]byte{0x01}
+	amount, err := ParseAmount("0.29")
+	require.NoError(t, err)
+	info := PackAddressAndValue(address[:], amount)
+	require.Equal(t, address[:], info[:20])
+	wei := uint256.NewInt(0).SetBytes(info[20:])
+	require.Equal(t, uint256.NewInt(29_000_000*1e10), wei)
+}
@@ -2,12 +2,14 @@ package bch
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"strings"
 	"time"
 
 	"github.com/gcash/bchd/btcjson"
 	"github.com/gcash/bchd/chaincfg/chainhash"
 	"github.com/gcash/bchd/rpcclient"
+	"github.com/gcash/bchd/wire"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -15,14 +17,15 @@ var _ IBchClient = &RetryableClient{}
 
 type IBchClient interface {
 	GetRawMempool() ([]*chainhash.Hash, error)
-	GetRawTransactionVerbose(txHash *chainhash.Hash) (*btcjson.TxRawResult, error)
+	GetRawTransactionVerbose(txHash *chainhash.Hash) (*TxRawResult, error)
 	GetTransaction(txHash *chainhash.Hash) (*btcjson.GetTransactionResult, error)
 	GetBlockCount() (int64, error)
 	GetBlockHash(blockHeight int64) (*chainhash.Hash, error)
-	GetBlockVerboseTx(blockHash *chainhash.Hash) (*btcjson.GetBlockVerboseTxResult, error)
+	GetBlockVerboseTx(blockHash *chainhash.Hash) (*GetBlockVerboseTxResult, error)
 	TestMempoolAccept(rawTx []byte) (bool, error)
 	SendRawTransaction(rawTx []byte) (*chainhash.Hash, error)
-	GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*btcjson.GetTxOutResult, error)
+	GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*GetTxOutResult, error)
+	GetBlockHeader(blockHash *chainhash.Hash) (*wire.BlockHeader, error)
 }
 
 type RetryableClient struct {
@@ -79,9 +82,26 @@ func (r *RetryableClient) GetRawMempool() (hashes []*chainhash.Hash, err error)
 	return
 }
 
-func (r *RetryableClient) GetRawTransactionVerbose(txHash *chainhash.Hash) (res *btcjson.TxRawResult, err error) {
+// rawRequest decodes the result by itself instead of rpcclient, so the amounts are exact
+func (r *RetryableClient) rawRequest(res interface{}, method string, params ...interface{}) error {
+	rawParams := make([]json.RawMessage, len(params))
+	for i, param := range params {
+		rawParam, err := json.Marshal(param)
+		if err != nil {
+			return err
+		}
+		rawParams[i] = rawParam
+	}
+	raw, err := r.client.RawRequest(method, rawParams)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, res)
+}
+
+func (r *RetryableClient) GetRawTransactionVerbose(txHash *chainhash.Hash) (res *TxRawResult, err error) {
 	for i := 0; i < r.maxRetry; i++ {
-		res, err = r.client.GetRawTransactionVerbose(txHash)
+		err = r.rawRequest(&res, "getrawtransaction", txHash.String(), 1)
 		if err == nil {
 			r.logger.Debug("getRawTransactionVerbose", "txHash", txHash.String(), "error", err)
 			return
@@ -127,9 +147,9 @@ func (r *RetryableClient) GetBlockHash(blockHeight int64) (hash *chainhash.Hash,
 	return
 }
 
-func (r *RetryableClient) GetBlockVerboseTx(blockHash *chainhash.Hash) (res *btcjson.GetBlockVerboseTxResult, err error) {
+func (r *RetryableClient) GetBlockVerboseTx(blockHash *chainhash.Hash) (res *GetBlockVerboseTxResult, err error) {
 	for i := 0; i < r.maxRetry; i++ {
-		res, err = r.client.GetBlockVerboseTx(blockHash)
+		err = r.rawRequest(&res, "getblock", blockHash.String(), 2)
 		if err == nil {
 			r.logger.Debug("getBlockVerboseTx", "blockHash", blockHash, "error", err)
 			return
@@ -139,6 +159,18 @@ func (r *RetryableClient) GetBlockVerboseTx(blockHash *chainhash.Hash) (res *btc
 	return
 }
 
+func (r *RetryableClient) GetBlockHeader(blockHash *chainhash.Hash) (header *wire.BlockHeader, err error) {
+	for i := 0; i < r.maxRetry; i++ {
+		header, err = r.client.GetBlockHeader(blockHash)
+		if err == nil {
+			r.logger.Debug("getBlockHeader", "blockHash", blockHash)
+			return
+		}
+		r.Delay()
+	}
+	return
+}
+
 func (r *RetryableClient) TestMempoolAccept(rawTx []byte) (ok bool, err error) {
 	for i := 0; i < r.maxRetry; i++ {
 		ok, err = testMempoolAccept("http://"+r.connCfg.Host, r.connCfg.User, r.connCfg.Pass, rawTx)
@@ -163,9 +195,10 @@ func (r *RetryableClient) SendRawTransaction(rawTx []byte) (txHash *chainhash.Ha
 	return
 }
 
-func (r *RetryableClient) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (txOut *btcjson.GetTxOutResult, err error) {
+func (r *RetryableClient) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (txOut *GetTxOutResult, err error) {
 	for i := 0; i < r.maxRetry; i++ {
-		txOut, err = r.client.GetTxOut(txHash, index, mempool)
+		// the result is null if the output is spent
+		err = r.rawRequest(&txOut, "gettxout", txHash.String(), index, mempool)
 		if err == nil {
 			r.logger.Debug("GetTxOut", "txHash", txHash, "index", index, "mempool", mempool)
 			return
@@ -11,6 +11,7 @@ import (
 	"github.com/gcash/bchd/txscript"
 	"github.com/gcash/bchutil"
 	"github.com/holiman/uint256"
+	"golang.org/x/crypto/ripemd160"
 
 	"github.com/elfinguard/chainlogs/types"
 )
@@ -107,25 +108,62 @@ func BuildLogData(confirmations *uint256.Int,
 		outputInfos, inputInfos, outputTokenInfos, inputTokenInfos, otherDataInOpReturn)
 }
 
-func ExtractSenderInfo(originTx *btcjson.TxRawResult, vout uint32, params *chaincfg.Params) (senderInfo [32]byte, err error) {
-	originVoutPkScript := originTx.Vout[vout].ScriptPubKey
-	originValue := originTx.Vout[vout].Value
-	if originVoutPkScript.Type == "pubkeyhash" || originVoutPkScript.Type == "scripthash" {
-		if len(originVoutPkScript.Addresses) != 1 {
-			err = fmt.Errorf("wrong address count")
-			return
-		}
-		senderAddr, _err := bchutil.DecodeAddress(originVoutPkScript.Addresses[0], params)
-		if _err != nil {
-			err = fmt.Errorf("failed to decode address: %w", err)
-			return
-		}
-		copy(senderInfo[:20], senderAddr.ScriptAddress())
-		amount := uint256.NewInt(0).Mul(uint256.NewInt(uint64(originValue*1e8)), uint256.NewInt(1e10)).Bytes20()
-		copy(senderInfo[20:], amount[8:])
+// PackAddressAndValue packs a 20-byte address and an amount into one word of EGTX log data,
+// the amount is converted to wei (1 satoshi = 1e10 wei) and stored in the lower 12 bytes.
+func PackAddressAndValue(address []byte, value Amount) (info [32]byte) {
+	copy(info[:20], address)
+	amount := uint256.NewInt(0).Mul(uint256.NewInt(uint64(value)), uint256.NewInt(1e10)).Bytes20()
+	copy(info[20:], amount[8:])
+	return
+}
+
+// OutputParser extracts the address info from the P2PKH and P2SH outputs of Bitcoin Cash transactions
+type OutputParser struct {
+	Params *chaincfg.Params
+}
+
+func NewOutputParser(params *chaincfg.Params) OutputParser {
+	return OutputParser{Params: params}
+}
+
+func (p OutputParser) IsAddressOutput(pkScript *btcjson.ScriptPubKeyResult) bool {
+	return pkScript.Type == "pubkeyhash" || pkScript.Type == "scripthash"
+}
+
+func (p OutputParser) ExtractOutputInfo(vout *Vout) (info [32]byte, err error) {
+	if !p.IsAddressOutput(&vout.ScriptPubKey) {
+		err = fmt.Errorf("invalid pkScript")
+		return
+	}
+	if len(vout.ScriptPubKey.Addresses) != 1 {
+		err = types.PubkeyScriptAddressNumInvalid
+		return
+	}
+	addr, _err := bchutil.DecodeAddress(vout.ScriptPubKey.Addresses[0], p.Params)
+	if _err != nil {
+		err = fmt.Errorf("failed to decode address: %w", _err)
 		return
 	}
+	return PackAddressAndValue(addr.ScriptAddress(), vout.Value), nil
+}
+
+func ExtractSenderInfo(originTx *TxRawResult, vout uint32, params *chaincfg.Params) (senderInfo [32]byte, err error) {
+	return NewOutputParser(params).ExtractOutputInfo(&originTx.Vout[vout])
+}
 
-	err = fmt.Errorf("invalid pkScript")
+// ConvertWitnessProgramToAddress maps a segwit program into the 20-byte address slot of EGTX logs.
+// 20-byte programs are kept as is, 32-byte programs are hashed with RIPEMD160, so a P2WSH output
+// is mapped to the HASH160 of its witness script, just like a P2SH output.
+func ConvertWitnessProgramToAddress(program []byte) (addr [20]byte, err error) {
+	switch len(program) {
+	case 20:
+		copy(addr[:], program)
+	case 32:
+		h := ripemd160.New()
+		h.Write(program)
+		copy(addr[:], h.Sum(nil))
+	default:
+		err = fmt.Errorf("invalid witness program length: %d", len(program))
+	}
 	return
 }
@@ -0,0 +1,28 @@
+package bch
+
+import "github.com/gcash/bchd/chaincfg/chainhash"
+
+// MerkleBranch returns the sibling hashes from the leaf at index up to the merkle root, and the root.
+// The last hash of a level with odd hashes is paired with itself, as bitcoin does.
+func MerkleBranch(txids []chainhash.Hash, index int) (branch []chainhash.Hash, root chainhash.Hash) {
+	level := append([]chainhash.Hash{}, txids...)
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		branch = append(branch, level[index^1])
+		next := make([]chainhash.Hash, len(level)/2)
+		for i := range next {
+			var pair [64]byte
+			copy(pair[:32], level[2*i][:])
+			copy(pair[32:], level[2*i+1][:])
+			next[i] = chainhash.DoubleHashH(pair[:])
+		}
+		level = next
+		index /= 2
+	}
+	if len(level) == 1 {
+		root = level[0]
+	}
+	return
+}
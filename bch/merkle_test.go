@@ -0,0 +1,56 @@
+package bch
+
+import (
+	"testing"
+
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func hashFromStr(s string) chainhash.Hash {
+	h, err := chainhash.NewHashFromStr(s)
+	if err != nil {
+		panic(err)
+	}
+	return *h
+}
+
+// block 170 of bitcoin has the first transfer
+func TestMerkleBranch_block170(t *testing.T) {
+	txids := []chainhash.Hash{
+		hashFromStr("b1fea52486ce0c62bb442b530a3f0132b826c74e473d1f2c220bfa78111c5082"),
+		hashFromStr("f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16"),
+	}
+	branch, root := MerkleBranch(txids, 1)
+	require.Equal(t, hashFromStr("7dac2c5666815c17a3b36427de37bb9d2e2c5ccec3f8633eb91a4205cb4c10ff"), root)
+	require.Equal(t, []chainhash.Hash{txids[0]}, branch)
+}
+
+func TestMerkleBranch(t *testing.T) {
+	txids := []chainhash.Hash{{0x01}, {0x02}, {0x03}, {0x04}, {0x05}}
+	_, root := MerkleBranch(txids, 0)
+	for i := range txids {
+		branch, r := MerkleBranch(txids, i)
+		require.Equal(t, root, r)
+		require.Len(t, branch, 3)
+		// fold the branch like a verifier
+		h, index := txids[i], i
+		for _, sibling := range branch {
+			var pair [64]byte
+			if index%2 == 0 {
+				copy(pair[:32], h[:])
+				copy(pair[32:], sibling[:])
+			} else {
+				copy(pair[:32], sibling[:])
+				copy(pair[32:], h[:])
+			}
+			h = chainhash.DoubleHashH(pair[:])
+			index /= 2
+		}
+		require.Equal(t, root, h)
+	}
+
+	branch, root := MerkleBranch(txids[:1], 0)
+	require.Empty(t, branch)
+	require.Equal(t, txids[0], root)
+}
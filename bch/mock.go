@@ -3,29 +3,50 @@ package bch
 import (
 	"encoding/hex"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/gcash/bchd/btcjson"
 	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/wire"
 )
 
 var _ IBchClient = &MockClient{}
 
 type MockClient struct {
 	txs        []*chainhash.Hash
-	txByHash   map[chainhash.Hash]*btcjson.TxRawResult
+	txByHash   map[chainhash.Hash]*TxRawResult
 	txToAccept map[string]bool
 	txToSend   map[string]*chainhash.Hash
-	txOuts     map[[33]byte]*btcjson.GetTxOutResult
+	txOuts     map[[33]byte]*GetTxOutResult
+	blocks     map[int64]*GetBlockVerboseTxResult
+	err        error
 }
 
-func (m *MockClient) AddTx(txHash *chainhash.Hash, tx *btcjson.TxRawResult) {
+// SetError makes all the following calls fail with err, like a node which is down, a nil err recovers it
+func (m *MockClient) SetError(err error) {
+	m.err = err
+}
+
+func (m *MockClient) AddTx(txHash *chainhash.Hash, tx *TxRawResult) {
 	m.txs = append(m.txs, txHash)
 	if m.txByHash == nil {
-		m.txByHash = map[chainhash.Hash]*btcjson.TxRawResult{}
+		m.txByHash = map[chainhash.Hash]*TxRawResult{}
 	}
 	m.txByHash[*txHash] = tx
 }
 
+// RemoveTx removes a tx from the mempool and the blockchain, like it is double spent
+func (m *MockClient) RemoveTx(txHash *chainhash.Hash) {
+	for i, h := range m.txs {
+		if *h == *txHash {
+			m.txs = append(m.txs[:i:i], m.txs[i+1:]...)
+			break
+		}
+	}
+	delete(m.txByHash, *txHash)
+}
+
 func (m *MockClient) AddTxToAccept(rawTx string) {
 	if m.txToAccept == nil {
 		m.txToAccept = map[string]bool{}
@@ -40,9 +61,9 @@ func (m *MockClient) AddTxToSend(rawTx string, txHash *chainhash.Hash) {
 	m.txToSend[rawTx] = txHash
 }
 
-func (m *MockClient) AddTxOut(txHash *chainhash.Hash, index uint32, result *btcjson.GetTxOutResult) {
+func (m *MockClient) AddTxOut(txHash *chainhash.Hash, index uint32, result *GetTxOutResult) {
 	if m.txOuts == nil {
-		m.txOuts = map[[33]byte]*btcjson.GetTxOutResult{}
+		m.txOuts = map[[33]byte]*GetTxOutResult{}
 	}
 	var key [33]byte
 	copy(key[:], (*txHash)[:])
@@ -50,45 +71,134 @@ func (m *MockClient) AddTxOut(txHash *chainhash.Hash, index uint32, result *btcj
 	m.txOuts[key] = result
 }
 
-func (m *MockClient) GetBlockVerboseTx(blockHash *chainhash.Hash) (*btcjson.GetBlockVerboseTxResult, error) {
+// AddBlock sets the main chain block at blk.Height, an existing block at the same height is replaced, just like a reorg
+func (m *MockClient) AddBlock(blk *GetBlockVerboseTxResult) {
+	if m.blocks == nil {
+		m.blocks = map[int64]*GetBlockVerboseTxResult{}
+	}
+	m.blocks[blk.Height] = blk
+}
+
+func (m *MockClient) GetBlockVerboseTx(blockHash *chainhash.Hash) (*GetBlockVerboseTxResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, blk := range m.blocks {
+		if blk.Hash == blockHash.String() {
+			return blk, nil
+		}
+	}
 	return nil, nil
 }
 
 func (m *MockClient) GetBlockCount() (int64, error) {
-	return 0, nil
+	if m.err != nil {
+		return 0, m.err
+	}
+	var count int64
+	for h := range m.blocks {
+		if h > count {
+			count = h
+		}
+	}
+	return count, nil
 }
 
 func (m *MockClient) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
-	return nil, nil
+	if m.err != nil {
+		return nil, m.err
+	}
+	blk := m.blocks[blockHeight]
+	if blk == nil {
+		return nil, nil
+	}
+	return chainhash.NewHashFromStr(blk.Hash)
 }
 
 func (m *MockClient) GetRawMempool() ([]*chainhash.Hash, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	return m.txs, nil
 }
 
-func (m *MockClient) GetRawTransactionVerbose(txHash *chainhash.Hash) (*btcjson.TxRawResult, error) {
+func (m *MockClient) GetRawTransactionVerbose(txHash *chainhash.Hash) (*TxRawResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	res := m.txByHash[*txHash]
 	if res == nil {
-		return nil, fmt.Errorf("tx not found: %s", txHash.String())
+		return nil, &btcjson.RPCError{Code: btcjson.ErrRPCNoTxInfo, Message: "tx not found: " + txHash.String()}
 	}
 	return res, nil
 }
 
 func (m *MockClient) GetTransaction(txHash *chainhash.Hash) (*btcjson.GetTransactionResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	return nil, nil
 }
 
 func (m *MockClient) TestMempoolAccept(rawTx []byte) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
 	return m.txToAccept[hex.EncodeToString(rawTx)], nil
 }
 
 func (m *MockClient) SendRawTransaction(rawTx []byte) (*chainhash.Hash, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	return m.txToSend[hex.EncodeToString(rawTx)], nil
 }
 
-func (m *MockClient) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*btcjson.GetTxOutResult, error) {
+func (m *MockClient) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*GetTxOutResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	var key [33]byte
 	copy(key[:], (*txHash)[:])
 	key[32] = byte(index)
 	return m.txOuts[key], nil
 }
+
+func (m *MockClient) GetBlockHeader(blockHash *chainhash.Hash) (*wire.BlockHeader, error) {
+	blk, err := m.GetBlockVerboseTx(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil {
+		return nil, fmt.Errorf("block not found: %s", blockHash.String())
+	}
+	return headerFromBlock(blk)
+}
+
+// headerFromBlock rebuilds the 80-byte block header from the fields of a verbose block
+func headerFromBlock(blk *GetBlockVerboseTxResult) (*wire.BlockHeader, error) {
+	var prevBlock chainhash.Hash
+	if blk.PreviousHash != "" {
+		hash, err := chainhash.NewHashFromStr(blk.PreviousHash)
+		if err != nil {
+			return nil, err
+		}
+		prevBlock = *hash
+	}
+	merkleRoot, err := chainhash.NewHashFromStr(blk.MerkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	bits, err := strconv.ParseUint(blk.Bits, 16, 32)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.BlockHeader{
+		Version:    blk.Version,
+		PrevBlock:  prevBlock,
+		MerkleRoot: *merkleRoot,
+		Timestamp:  time.Unix(blk.Time, 0),
+		Bits:       uint32(bits),
+		Nonce:      blk.Nonce,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package bch
+
+import (
+	"testing"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/stretchr/testify/require"
+)
+
+// the header of block 170 of bitcoin is rebuilt from its verbose block
+func TestHeaderFromBlock(t *testing.T) {
+	blk := &GetBlockVerboseTxResult{GetBlockVerboseTxResult: btcjson.GetBlockVerboseTxResult{
+		Hash:         "00000000d1145790a8694403d4063f323d499e655c83426834d4ce2f8dd4a2ee",
+		Height:       170,
+		Version:      1,
+		MerkleRoot:   "7dac2c5666815c17a3b36427de37bb9d2e2c5ccec3f8633eb91a4205cb4c10ff",
+		Time:         1231731025,
+		Nonce:        1889418792,
+		Bits:         "1d00ffff",
+		PreviousHash: "000000002a22cfee1f2c846adbd12b3e183d4f97683f85dad08a79780a84bd55",
+	}}
+	header, err := headerFromBlock(blk)
+	require.NoError(t, err)
+	require.Equal(t, blk.Hash, header.BlockHash().String())
+
+	blk.Bits = "xyz"
+	_, err = headerFromBlock(blk)
+	require.Error(t, err)
+}
@@ -0,0 +1,320 @@
+package bch
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/wire"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+var _ IBchClient = &MultiClient{}
+var _ IEndpointReporter = &MultiClient{}
+
+// MaxEndpointFailures is the count of consecutive failures after which an endpoint is taken as unhealthy
+const MaxEndpointFailures = 3
+
+type Endpoint struct {
+	Url    string
+	Client IBchClient // it should not retry by itself, MultiClient and QuorumClient retry on the other endpoints
+}
+
+// EndpointStatus is reported by MultiClient for every main chain node
+type EndpointStatus struct {
+	Url        string `json:"url"`
+	Active     bool   `json:"active"`
+	Healthy    bool   `json:"healthy"`
+	BlockCount int64  `json:"blockCount"`
+	Failures   int    `json:"failures"`
+}
+
+// IEndpointReporter is implemented by the clients connecting to several main chain nodes
+type IEndpointReporter interface {
+	EndpointStatuses() []EndpointStatus
+}
+
+type endpoint struct {
+	url        string
+	client     IBchClient
+	healthy    bool
+	blockCount int64
+	failures   int // consecutive failures
+}
+
+// MultiClient connects to several main chain nodes. The node with the highest block count is used, and
+// it switches to the next healthy node after MaxEndpointFailures consecutive errors.
+type MultiClient struct {
+	mtx       sync.Mutex
+	endpoints []*endpoint
+	active    int
+
+	delay    int64 // sleep delay second when all endpoints failed
+	maxRetry int
+	sleep    func(d time.Duration)
+	logger   log.Logger
+}
+
+// NewMultiClient checks the health of all endpoints at once, and then every healthCheckInterval if it is not zero
+func NewMultiClient(endpoints []Endpoint, healthCheckInterval time.Duration, delayTime int64, maxRetry int, logger log.Logger) *MultiClient {
+	if len(endpoints) == 0 {
+		panic("no main chain client info")
+	}
+	m := &MultiClient{
+		delay:    delayTime,
+		maxRetry: maxRetry,
+		sleep:    time.Sleep,
+		logger:   logger,
+	}
+	for _, e := range endpoints {
+		m.endpoints = append(m.endpoints, &endpoint{url: e.Url, client: e.Client, healthy: true})
+	}
+	m.CheckHealth()
+	if healthCheckInterval > 0 {
+		go m.healthCheckLoop(healthCheckInterval)
+	}
+	return m
+}
+
+// NewMultiRetryableClient makes a MultiClient whose endpoints are RetryableClients in the format of url,username,password
+func NewMultiRetryableClient(mainChainClientInfos []string, delayTime int64, maxRetry int, logger log.Logger) *MultiClient {
+	return NewMultiClient(NewEndpoints(mainChainClientInfos, logger), time.Minute, delayTime, maxRetry, logger)
+}
+
+// NewEndpoints makes RetryableClients which do not retry, the callers retry on the other endpoints
+func NewEndpoints(mainChainClientInfos []string, logger log.Logger) []Endpoint {
+	endpoints := make([]Endpoint, len(mainChainClientInfos))
+	for i, info := range mainChainClientInfos {
+		endpoints[i] = Endpoint{
+			Url:    ClientInfoUrl(info),
+			Client: NewRetryableClient(info, 0, 1, logger),
+		}
+	}
+	return endpoints
+}
+
+// ClientInfoUrl returns the url part of the client info, without the username and password
+func ClientInfoUrl(mainChainClientInfo string) string {
+	return strings.Split(mainChainClientInfo, ",")[0]
+}
+
+func (m *MultiClient) healthCheckLoop(interval time.Duration) {
+	for range time.Tick(interval) {
+		m.CheckHealth()
+	}
+}
+
+// CheckHealth queries the block counts of all endpoints, and switches to the one with the highest block count
+func (m *MultiClient) CheckHealth() {
+	counts := make([]int64, len(m.endpoints))
+	errs := make([]error, len(m.endpoints))
+	for i, e := range m.endpoints {
+		counts[i], errs[i] = e.client.GetBlockCount()
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for i, e := range m.endpoints {
+		if errs[i] != nil {
+			e.healthy = false
+			m.logger.Info("main chain endpoint is unhealthy", "url", e.url, "error", errs[i])
+			continue
+		}
+		e.healthy = true
+		e.failures = 0
+		e.blockCount = counts[i]
+	}
+	best := m.active
+	for i, e := range m.endpoints {
+		if e.healthy && (!m.endpoints[best].healthy || e.blockCount > m.endpoints[best].blockCount) {
+			best = i
+		}
+	}
+	if best != m.active {
+		reason := "higher block count"
+		if !m.endpoints[m.active].healthy {
+			reason = "active endpoint is unhealthy"
+		}
+		m.switchTo(best, reason)
+	}
+}
+
+// ActiveEndpoint returns the url of the endpoint in use
+func (m *MultiClient) ActiveEndpoint() string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.endpoints[m.active].url
+}
+
+func (m *MultiClient) EndpointStatuses() []EndpointStatus {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	statuses := make([]EndpointStatus, len(m.endpoints))
+	for i, e := range m.endpoints {
+		statuses[i] = EndpointStatus{
+			Url:        e.url,
+			Active:     i == m.active,
+			Healthy:    e.healthy,
+			BlockCount: e.blockCount,
+			Failures:   e.failures,
+		}
+	}
+	return statuses
+}
+
+// should be called with mtx held
+func (m *MultiClient) switchTo(idx int, reason string) {
+	m.logger.Info("switch main chain endpoint", "from", m.endpoints[m.active].url, "to", m.endpoints[idx].url, "reason", reason)
+	m.active = idx
+}
+
+func (m *MultiClient) activeClient() (int, IBchClient) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.active, m.endpoints[m.active].client
+}
+
+// onError returns true if another endpoint is switched to
+func (m *MultiClient) onError(idx int, method string, err error) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e := m.endpoints[idx]
+	e.failures++
+	m.logger.Debug("main chain endpoint error", "url", e.url, "method", method, "failures", e.failures, "error", err)
+	if e.failures < MaxEndpointFailures {
+		return false
+	}
+	e.healthy = false
+	if idx != m.active {
+		return true // switched by others
+	}
+	for i := 1; i < len(m.endpoints); i++ {
+		next := (idx + i) % len(m.endpoints)
+		if m.endpoints[next].healthy {
+			m.switchTo(next, "too many errors")
+			return true
+		}
+	}
+	// all endpoints are unhealthy, try them one by one until one recovers
+	if len(m.endpoints) > 1 {
+		e.failures = 0
+		m.switchTo((idx+1)%len(m.endpoints), "all endpoints are unhealthy")
+		return true
+	}
+	return false
+}
+
+func (m *MultiClient) onSuccess(idx int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.endpoints[idx].failures = 0
+	m.endpoints[idx].healthy = true
+}
+
+// call retries on the next endpoint at once after a switch, and sleeps once every endpoint has failed since the
+// last sleep, so dead endpoints are not called back-to-back
+func (m *MultiClient) call(method string, fn func(c IBchClient) error) (err error) {
+	tried := make(map[int]bool, len(m.endpoints))
+	for i := 0; i < m.maxRetry; i++ {
+		idx, c := m.activeClient()
+		tried[idx] = true
+		err = fn(c)
+		var rpcErr *btcjson.RPCError
+		if err == nil || errors.As(err, &rpcErr) {
+			// the node is working if it returns an RPC error, such as the tx is not found
+			m.onSuccess(idx)
+			return
+		}
+		if m.onError(idx, method, err) {
+			if next, _ := m.activeClient(); !tried[next] {
+				continue
+			}
+		}
+		m.sleep(time.Duration(m.delay) * time.Second)
+		tried = make(map[int]bool, len(m.endpoints))
+	}
+	return
+}
+
+func (m *MultiClient) GetRawMempool() (hashes []*chainhash.Hash, err error) {
+	err = m.call("getRawMempool", func(c IBchClient) (err error) {
+		hashes, err = c.GetRawMempool()
+		return
+	})
+	return
+}
+
+func (m *MultiClient) GetRawTransactionVerbose(txHash *chainhash.Hash) (res *TxRawResult, err error) {
+	err = m.call("getRawTransactionVerbose", func(c IBchClient) (err error) {
+		res, err = c.GetRawTransactionVerbose(txHash)
+		return
+	})
+	return
+}
+
+func (m *MultiClient) GetTransaction(txHash *chainhash.Hash) (res *btcjson.GetTransactionResult, err error) {
+	err = m.call("getTransaction", func(c IBchClient) (err error) {
+		res, err = c.GetTransaction(txHash)
+		return
+	})
+	return
+}
+
+func (m *MultiClient) GetBlockCount() (count int64, err error) {
+	err = m.call("getBlockCount", func(c IBchClient) (err error) {
+		count, err = c.GetBlockCount()
+		return
+	})
+	return
+}
+
+func (m *MultiClient) GetBlockHash(blockHeight int64) (hash *chainhash.Hash, err error) {
+	err = m.call("getBlockHash", func(c IBchClient) (err error) {
+		hash, err = c.GetBlockHash(blockHeight)
+		return
+	})
+	return
+}
+
+func (m *MultiClient) GetBlockVerboseTx(blockHash *chainhash.Hash) (res *GetBlockVerboseTxResult, err error) {
+	err = m.call("getBlockVerboseTx", func(c IBchClient) (err error) {
+		res, err = c.GetBlockVerboseTx(blockHash)
+		return
+	})
+	return
+}
+
+func (m *MultiClient) GetBlockHeader(blockHash *chainhash.Hash) (header *wire.BlockHeader, err error) {
+	err = m.call("getBlockHeader", func(c IBchClient) (err error) {
+		header, err = c.GetBlockHeader(blockHash)
+		return
+	})
+	return
+}
+
+func (m *MultiClient) TestMempoolAccept(rawTx []byte) (ok bool, err error) {
+	err = m.call("testMempoolAccept", func(c IBchClient) (err error) {
+		ok, err = c.TestMempoolAccept(rawTx)
+		return
+	})
+	return
+}
+
+func (m *MultiClient) SendRawTransaction(rawTx []byte) (txHash *chainhash.Hash, err error) {
+	err = m.call("sendRawTransaction", func(c IBchClient) (err error) {
+		txHash, err = c.SendRawTransaction(rawTx)
+		return
+	})
+	return
+}
+
+func (m *MultiClient) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (txOut *GetTxOutResult, err error) {
+	err = m.call("getTxOut", func(c IBchClient) (err error) {
+		txOut, err = c.GetTxOut(txHash, index, mempool)
+		return
+	})
+	return
+}
@@ -0,0 +1,105 @@
+package bch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func newMockNode(height int64) *MockClient {
+	m := &MockClient{}
+	for h := int64(1); h <= height; h++ {
+		m.AddBlock(newMockBlock(chainhash.Hash{byte(h)}, h))
+	}
+	return m
+}
+
+func newMockBlock(hash chainhash.Hash, height int64) *GetBlockVerboseTxResult {
+	return &GetBlockVerboseTxResult{GetBlockVerboseTxResult: btcjson.GetBlockVerboseTxResult{Hash: hash.String(), Height: height}}
+}
+
+func TestMultiClientPrefersHighestBlockCount(t *testing.T) {
+	n0, n1, n2 := newMockNode(10), newMockNode(12), newMockNode(11)
+	m := NewMultiClient([]Endpoint{{"n0", n0}, {"n1", n1}, {"n2", n2}}, 0, 0, 10, log.NewNopLogger())
+	require.Equal(t, "n1", m.ActiveEndpoint())
+	count, err := m.GetBlockCount()
+	require.NoError(t, err)
+	require.EqualValues(t, 12, count)
+
+	n2.AddBlock(newMockBlock(chainhash.Hash{13}, 13))
+	m.CheckHealth()
+	require.Equal(t, "n2", m.ActiveEndpoint())
+
+	// a node with the same block count does not take over
+	n1.AddBlock(newMockBlock(chainhash.Hash{13}, 13))
+	m.CheckHealth()
+	require.Equal(t, "n2", m.ActiveEndpoint())
+	statuses := m.EndpointStatuses()
+	require.Len(t, statuses, 3)
+	require.Equal(t, EndpointStatus{Url: "n2", Active: true, Healthy: true, BlockCount: 13}, statuses[2])
+	require.False(t, statuses[1].Active)
+}
+
+func TestMultiClientFailover(t *testing.T) {
+	n0, n1 := newMockNode(10), newMockNode(10)
+	m := NewMultiClient([]Endpoint{{"n0", n0}, {"n1", n1}}, 0, 0, 10, log.NewNopLogger())
+	require.Equal(t, "n0", m.ActiveEndpoint())
+
+	n0.SetError(errors.New("connection refused"))
+	hash, err := m.GetBlockHash(3)
+	require.NoError(t, err)
+	require.Equal(t, chainhash.Hash{3}, *hash)
+	require.Equal(t, "n1", m.ActiveEndpoint())
+	statuses := m.EndpointStatuses()
+	require.False(t, statuses[0].Healthy)
+	require.Equal(t, MaxEndpointFailures, statuses[0].Failures)
+
+	// an RPC error means the node is working
+	n1.SetError(&btcjson.RPCError{Code: btcjson.ErrRPCNoTxInfo, Message: "No such mempool or blockchain transaction"})
+	_, err = m.GetRawTransactionVerbose(&chainhash.Hash{})
+	require.Error(t, err)
+	require.Equal(t, "n1", m.ActiveEndpoint())
+	require.Equal(t, 0, m.EndpointStatuses()[1].Failures)
+
+	// all nodes are down
+	n1.SetError(errors.New("connection refused"))
+	_, err = m.GetBlockCount()
+	require.Error(t, err)
+
+	// recovered node is used again
+	n0.SetError(nil)
+	count, err := m.GetBlockCount()
+	require.NoError(t, err)
+	require.EqualValues(t, 10, count)
+	require.Equal(t, "n0", m.ActiveEndpoint())
+}
+
+func TestMultiClientSleepsWhenAllEndpointsFail(t *testing.T) {
+	n0, n1, n2 := newMockNode(10), newMockNode(10), newMockNode(10)
+	m := NewMultiClient([]Endpoint{{"n0", n0}, {"n1", n1}, {"n2", n2}}, 0, 1, 30, log.NewNopLogger())
+	calls, callsSinceSleep, sleeps := 0, 0, 0
+	m.sleep = func(d time.Duration) {
+		require.Equal(t, time.Second, d)
+		sleeps++
+		callsSinceSleep = 0
+	}
+	for _, n := range []*MockClient{n0, n1, n2} {
+		n.SetError(errors.New("connection refused"))
+	}
+	err := m.call("getBlockCount", func(c IBchClient) error {
+		calls++
+		callsSinceSleep++
+		// no endpoint is called twice between two sleeps
+		require.LessOrEqual(t, callsSinceSleep, 3)
+		_, err := c.GetBlockCount()
+		return err
+	})
+	require.Error(t, err)
+	require.Equal(t, 30, calls)
+	require.GreaterOrEqual(t, sleeps, 10)
+}
@@ -0,0 +1,23 @@
+package bch
+
+import (
+	"fmt"
+
+	"github.com/gcash/bchd/chaincfg"
+)
+
+// GetNetParams returns the params of a Bitcoin Cash network.
+// Chipnet shares the address encodings with testnet4, so their params are the same.
+func GetNetParams(network string) (*chaincfg.Params, error) {
+	switch network {
+	case "mainnet":
+		return &chaincfg.MainNetParams, nil
+	case "testnet3":
+		return &chaincfg.TestNet3Params, nil
+	case "testnet4", "chipnet":
+		return &chaincfg.TestNet4Params, nil
+	case "regtest":
+		return &chaincfg.RegressionNetParams, nil
+	}
+	return nil, fmt.Errorf("unsupported network: %s", network)
+}
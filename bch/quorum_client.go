@@ -0,0 +1,227 @@
+package bch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/wire"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+var _ IBchClient = &QuorumClient{}
+var _ IEndpointReporter = &QuorumClient{}
+
+var (
+	// ErrNoQuorum means enough nodes answer but they disagree
+	ErrNoQuorum = errors.New("main chain nodes do not reach quorum")
+	// ErrNotEnoughNodes means too many nodes fail to answer after all retries, which is a node failure
+	ErrNotEnoughNodes = errors.New("not enough main chain nodes answer")
+)
+
+// QuorumClient cross-checks the results which EGTXs are derived from. GetRawTransactionVerbose, GetBlockHash
+// and GetBlockVerboseTx are sent to all the endpoints, and a result is accepted only when at least quorum
+// endpoints agree on it. The other methods are sent to the primary client.
+type QuorumClient struct {
+	primary   IBchClient
+	endpoints []Endpoint
+	quorum    int
+
+	// halt the process when the endpoints disagree, otherwise ErrNoQuorum is returned and the result is skipped
+	haltOnDisagreement bool
+
+	delay    int64 // sleep delay second when retry
+	maxRetry int
+	logger   log.Logger
+}
+
+func NewQuorumClient(primary IBchClient, endpoints []Endpoint, quorum int, haltOnDisagreement bool,
+	delayTime int64, maxRetry int, logger log.Logger) *QuorumClient {
+	if quorum <= 0 || quorum > len(endpoints) {
+		panic(fmt.Sprintf("invalid quorum %d of %d main chain nodes", quorum, len(endpoints)))
+	}
+	return &QuorumClient{
+		primary:            primary,
+		endpoints:          endpoints,
+		quorum:             quorum,
+		haltOnDisagreement: haltOnDisagreement,
+		delay:              delayTime,
+		maxRetry:           maxRetry,
+		logger:             logger,
+	}
+}
+
+type vote struct {
+	result   interface{}
+	key      string
+	err      error
+	answered bool
+}
+
+// query retries if too many endpoints fail, and returns ErrNoQuorum at once if enough endpoints answer but disagree.
+// fn returns the result and the key to compare it, which should exclude the fields differing from node to node.
+func (q *QuorumClient) query(method string, fn func(c IBchClient) (interface{}, string, error)) (interface{}, error) {
+	for i := 0; i < q.maxRetry; i++ {
+		votes := make([]vote, len(q.endpoints))
+		var wg sync.WaitGroup
+		for j, e := range q.endpoints {
+			wg.Add(1)
+			go func(j int, c IBchClient) {
+				defer wg.Done()
+				v := &votes[j]
+				v.result, v.key, v.err = fn(c)
+				var rpcErr *btcjson.RPCError
+				if errors.As(v.err, &rpcErr) {
+					// the node answers with an error, such as the tx is not found, it is also a vote
+					v.key, v.answered = "error: "+rpcErr.Error(), true
+				} else {
+					v.answered = v.err == nil
+				}
+			}(j, e.Client)
+		}
+		wg.Wait()
+
+		counts := make(map[string]int)
+		answered := 0
+		for _, v := range votes {
+			if v.answered {
+				answered++
+				counts[v.key]++
+			}
+		}
+		for _, v := range votes {
+			if v.answered && counts[v.key] >= q.quorum {
+				return v.result, v.err
+			}
+		}
+		if answered >= q.quorum {
+			q.onDisagreement(method, votes)
+			return nil, ErrNoQuorum
+		}
+		q.logger.Debug("main chain nodes not enough", "method", method, "answered", answered, "quorum", q.quorum)
+		time.Sleep(time.Duration(q.delay) * time.Second)
+	}
+	return nil, ErrNotEnoughNodes
+}
+
+func (q *QuorumClient) onDisagreement(method string, votes []vote) {
+	keyvals := []interface{}{"method", method, "quorum", q.quorum}
+	for i, v := range votes {
+		answer := v.key
+		if !v.answered {
+			answer = "error: " + v.err.Error()
+		}
+		keyvals = append(keyvals, q.endpoints[i].Url, answer)
+	}
+	q.logger.Error("main chain nodes disagree", keyvals...)
+	if q.haltOnDisagreement {
+		panic(fmt.Sprintf("main chain nodes disagree on %s", method))
+	}
+}
+
+func jsonKey(v interface{}) string {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
+}
+
+// txKey excludes the fields depending on the node's tip
+func txKey(tx TxRawResult) string {
+	tx.Confirmations = 0
+	tx.BlockHash = ""
+	tx.Time = 0
+	tx.Blocktime = 0
+	return jsonKey(tx)
+}
+
+func (q *QuorumClient) GetRawTransactionVerbose(txHash *chainhash.Hash) (*TxRawResult, error) {
+	res, err := q.query("getRawTransactionVerbose", func(c IBchClient) (interface{}, string, error) {
+		tx, err := c.GetRawTransactionVerbose(txHash)
+		if err != nil || tx == nil {
+			return tx, "null", err
+		}
+		return tx, txKey(*tx), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*TxRawResult), nil
+}
+
+func (q *QuorumClient) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	res, err := q.query("getBlockHash", func(c IBchClient) (interface{}, string, error) {
+		hash, err := c.GetBlockHash(blockHeight)
+		if err != nil || hash == nil {
+			return hash, "null", err
+		}
+		return hash, hash.String(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*chainhash.Hash), nil
+}
+
+func (q *QuorumClient) GetBlockVerboseTx(blockHash *chainhash.Hash) (*GetBlockVerboseTxResult, error) {
+	res, err := q.query("getBlockVerboseTx", func(c IBchClient) (interface{}, string, error) {
+		blk, err := c.GetBlockVerboseTx(blockHash)
+		if err != nil || blk == nil {
+			return blk, "null", err
+		}
+		b := *blk
+		b.Confirmations = 0
+		b.NextHash = ""
+		b.Tx = nil
+		key := jsonKey(b)
+		for _, tx := range blk.Tx {
+			key += txKey(tx)
+		}
+		return blk, key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*GetBlockVerboseTxResult), nil
+}
+
+// EndpointStatuses reports the primary client's endpoints
+func (q *QuorumClient) EndpointStatuses() []EndpointStatus {
+	if r, ok := q.primary.(IEndpointReporter); ok {
+		return r.EndpointStatuses()
+	}
+	return nil
+}
+
+func (q *QuorumClient) GetRawMempool() ([]*chainhash.Hash, error) {
+	return q.primary.GetRawMempool()
+}
+
+func (q *QuorumClient) GetTransaction(txHash *chainhash.Hash) (*btcjson.GetTransactionResult, error) {
+	return q.primary.GetTransaction(txHash)
+}
+
+func (q *QuorumClient) GetBlockCount() (int64, error) {
+	return q.primary.GetBlockCount()
+}
+
+func (q *QuorumClient) TestMempoolAccept(rawTx []byte) (bool, error) {
+	return q.primary.TestMempoolAccept(rawTx)
+}
+
+func (q *QuorumClient) SendRawTransaction(rawTx []byte) (*chainhash.Hash, error) {
+	return q.primary.SendRawTransaction(rawTx)
+}
+
+func (q *QuorumClient) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*GetTxOutResult, error) {
+	return q.primary.GetTxOut(txHash, index, mempool)
+}
+
+func (q *QuorumClient) GetBlockHeader(blockHash *chainhash.Hash) (*wire.BlockHeader, error) {
+	return q.primary.GetBlockHeader(blockHash)
+}
@@ -0,0 +1,83 @@
+package bch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func newQuorumClient(quorum int, halt bool, nodes ...*MockClient) *QuorumClient {
+	var endpoints []Endpoint
+	for i, n := range nodes {
+		endpoints = append(endpoints, Endpoint{Url: string(rune('a' + i)), Client: n})
+	}
+	return NewQuorumClient(nodes[0], endpoints, quorum, halt, 0, 3, log.NewNopLogger())
+}
+
+func TestQuorumClientBlockHash(t *testing.T) {
+	n0, n1, n2 := newMockNode(5), newMockNode(5), newMockNode(5)
+	q := newQuorumClient(2, false, n0, n1, n2)
+	hash, err := q.GetBlockHash(5)
+	require.NoError(t, err)
+	require.Equal(t, chainhash.Hash{5}, *hash)
+
+	// one node is on another fork
+	n2.AddBlock(newMockBlock(chainhash.Hash{0xff}, 5))
+	hash, err = q.GetBlockHash(5)
+	require.NoError(t, err)
+	require.Equal(t, chainhash.Hash{5}, *hash)
+
+	// one node is down, the other two still agree
+	n2.SetError(errors.New("connection refused"))
+	blk, err := q.GetBlockVerboseTx(hash)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, blk.Height)
+
+	// no quorum
+	n1.AddBlock(newMockBlock(chainhash.Hash{0xfe}, 5))
+	_, err = q.GetBlockHash(5)
+	require.ErrorIs(t, err, ErrNoQuorum)
+
+	q.haltOnDisagreement = true
+	require.Panics(t, func() {
+		_, _ = q.GetBlockHash(5)
+	})
+}
+
+func TestQuorumClientTx(t *testing.T) {
+	txHash := chainhash.Hash{1}
+	newTx := func(value Amount, confirmations uint64) *TxRawResult {
+		return &TxRawResult{
+			TxRawResult: btcjson.TxRawResult{Txid: txHash.String(), Hex: "0100", Confirmations: confirmations},
+			Vout:        []Vout{{Value: value}},
+		}
+	}
+	tx := newTx(1e8, 0)
+	n0, n1, n2 := &MockClient{}, &MockClient{}, &MockClient{}
+	n0.AddTx(&txHash, tx)
+	// the confirmations depend on the node's tip
+	n1.AddTx(&txHash, newTx(1e8, 2))
+	// a lying node
+	n2.AddTx(&txHash, newTx(100e8, 0))
+
+	q := newQuorumClient(2, false, n0, n1, n2)
+	res, err := q.GetRawTransactionVerbose(&txHash)
+	require.NoError(t, err)
+	require.Equal(t, tx, res)
+
+	q = newQuorumClient(3, false, n0, n1, n2)
+	_, err = q.GetRawTransactionVerbose(&txHash)
+	require.ErrorIs(t, err, ErrNoQuorum)
+
+	// the nodes agree that the tx is not found
+	notFound := &btcjson.RPCError{Code: btcjson.ErrRPCNoTxInfo, Message: "No such mempool or blockchain transaction"}
+	n0.SetError(notFound)
+	n1.SetError(notFound)
+	q = newQuorumClient(2, false, n0, n1, n2)
+	_, err = q.GetRawTransactionVerbose(&txHash)
+	require.Equal(t, notFound, err)
+}
@@ -0,0 +1,139 @@
+package bch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/gcash/bchd/chaincfg/chainhash"
+)
+
+var egtxFlagBytes, _ = hex.DecodeString(EGTXFlag)
+
+var ErrInvalidRawTx = errors.New("invalid raw tx")
+
+// ParseRawTx returns the txid of a serialized tx and whether its first output is an EGTX OP_RETURN.
+// It only walks through the tx, so it works for the txs of Bitcoin Cash, Bitcoin, Litecoin and Dogecoin,
+// the witnesses (BIP144) are excluded from the txid.
+func ParseRawTx(rawTx []byte) (txid chainhash.Hash, isEGTX bool, err error) {
+	r := bytes.NewReader(rawTx)
+	var stripped bytes.Buffer // the tx without witnesses
+	copyN := func(n uint64) ([]byte, error) {
+		if n > uint64(r.Len()) {
+			return nil, ErrInvalidRawTx
+		}
+		bz := make([]byte, n)
+		_, _ = io.ReadFull(r, bz)
+		stripped.Write(bz)
+		return bz, nil
+	}
+	readVarInt := func(keep bool) (uint64, error) {
+		start := r.Len()
+		n, err := readCompactSize(r)
+		if err != nil {
+			return 0, err
+		}
+		if keep {
+			stripped.Write(rawTx[len(rawTx)-start : len(rawTx)-r.Len()])
+		}
+		return n, nil
+	}
+
+	if _, err = copyN(4); err != nil { // version
+		return
+	}
+	segwit := len(rawTx) > 6 && rawTx[4] == 0 && rawTx[5] == 1
+	if segwit {
+		_, _ = r.Seek(2, io.SeekCurrent)
+	}
+	inCount, err := readVarInt(true)
+	if err != nil {
+		return
+	}
+	for i := uint64(0); i < inCount; i++ {
+		if _, err = copyN(36); err != nil { // outpoint
+			return
+		}
+		var n uint64
+		if n, err = readVarInt(true); err != nil {
+			return
+		}
+		if _, err = copyN(n + 4); err != nil { // unlocking script and sequence
+			return
+		}
+	}
+	outCount, err := readVarInt(true)
+	if err != nil {
+		return
+	}
+	for i := uint64(0); i < outCount; i++ {
+		if _, err = copyN(8); err != nil { // value
+			return
+		}
+		var n uint64
+		if n, err = readVarInt(true); err != nil {
+			return
+		}
+		var script []byte
+		if script, err = copyN(n); err != nil {
+			return
+		}
+		if i == 0 {
+			isEGTX = bytes.HasPrefix(script, egtxFlagBytes)
+		}
+	}
+	if segwit {
+		for i := uint64(0); i < inCount; i++ {
+			var items uint64
+			if items, err = readVarInt(false); err != nil {
+				return
+			}
+			for j := uint64(0); j < items; j++ {
+				var n uint64
+				if n, err = readVarInt(false); err != nil {
+					return
+				}
+				if n > uint64(r.Len()) {
+					err = ErrInvalidRawTx
+					return
+				}
+				_, _ = r.Seek(int64(n), io.SeekCurrent)
+			}
+		}
+	}
+	if _, err = copyN(4); err != nil { // lock time
+		return
+	}
+	if r.Len() != 0 {
+		// such as Litecoin's MWEB txs
+		err = ErrInvalidRawTx
+		return
+	}
+	txid = chainhash.DoubleHashH(stripped.Bytes())
+	return
+}
+
+func readCompactSize(r *bytes.Reader) (uint64, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, ErrInvalidRawTx
+	}
+	var size int
+	switch b {
+	case 0xfd:
+		size = 2
+	case 0xfe:
+		size = 4
+	case 0xff:
+		size = 8
+	default:
+		return uint64(b), nil
+	}
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:size]); err != nil {
+		return 0, ErrInvalidRawTx
+	}
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
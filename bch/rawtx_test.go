@@ -0,0 +1,49 @@
+package bch
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRawTx(t *testing.T) {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 3), []byte{0x51}))
+	tx.AddTxOut(wire.NewTxOut(0, append(egtxFlagBytes, 0x01, 0xaa)))
+	tx.AddTxOut(wire.NewTxOut(1000, bytes.Repeat([]byte{0x76}, 300)))
+	var buf bytes.Buffer
+	require.NoError(t, tx.Serialize(&buf))
+	raw := buf.Bytes()
+
+	txid, isEGTX, err := ParseRawTx(raw)
+	require.NoError(t, err)
+	require.True(t, isEGTX)
+	require.Equal(t, tx.TxHash(), txid)
+
+	// the same tx with a witness has the same txid
+	segwitRaw := append([]byte{}, raw[:4]...)
+	segwitRaw = append(segwitRaw, 0, 1)
+	segwitRaw = append(segwitRaw, raw[4:len(raw)-4]...)
+	segwitRaw = append(segwitRaw, 2, 1, 0xab, 2, 0xcd, 0xef)
+	segwitRaw = append(segwitRaw, raw[len(raw)-4:]...)
+	txid, isEGTX, err = ParseRawTx(segwitRaw)
+	require.NoError(t, err)
+	require.True(t, isEGTX)
+	require.Equal(t, tx.TxHash(), txid)
+
+	tx.TxOut[0], tx.TxOut[1] = tx.TxOut[1], tx.TxOut[0]
+	buf.Reset()
+	require.NoError(t, tx.Serialize(&buf))
+	txid, isEGTX, err = ParseRawTx(buf.Bytes())
+	require.NoError(t, err)
+	require.False(t, isEGTX)
+	require.Equal(t, tx.TxHash(), txid)
+
+	_, _, err = ParseRawTx(raw[:len(raw)-1])
+	require.ErrorIs(t, err, ErrInvalidRawTx)
+	_, _, err = ParseRawTx(append(raw, 0))
+	require.ErrorIs(t, err, ErrInvalidRawTx)
+}
@@ -0,0 +1,64 @@
+package btc
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/txscript"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+// OutputParser extracts the address info from the P2PKH, P2SH, P2WPKH, P2WSH and P2TR outputs of Bitcoin transactions.
+// Bitcoin Core (v22+) returns one 'address' instead of 'addresses' in scriptPubKey, so the address is extracted from
+// the script itself. The 20-byte hashes and witness programs are used as addresses, and 32-byte witness programs
+// are mapped by bch.ConvertWitnessProgramToAddress.
+type OutputParser struct{}
+
+func NewOutputParser() OutputParser {
+	return OutputParser{}
+}
+
+func (p OutputParser) IsAddressOutput(pkScript *btcjson.ScriptPubKeyResult) bool {
+	switch pkScript.Type {
+	case "pubkeyhash", "scripthash", "witness_v0_keyhash", "witness_v0_scripthash", "witness_v1_taproot":
+		return true
+	}
+	return false
+}
+
+func (p OutputParser) ExtractOutputInfo(vout *bch.Vout) (info [32]byte, err error) {
+	if !p.IsAddressOutput(&vout.ScriptPubKey) {
+		err = fmt.Errorf("invalid pkScript")
+		return
+	}
+	script, err := hex.DecodeString(vout.ScriptPubKey.Hex)
+	if err != nil {
+		return
+	}
+	addr, err := ExtractAddress(script)
+	if err != nil {
+		return
+	}
+	return bch.PackAddressAndValue(addr[:], vout.Value), nil
+}
+
+// ExtractAddress returns the 20-byte address of a standard pkScript
+func ExtractAddress(script []byte) (addr [20]byte, err error) {
+	switch {
+	case len(script) == 25 && script[0] == txscript.OP_DUP && script[1] == txscript.OP_HASH160 &&
+		script[2] == txscript.OP_DATA_20 && script[23] == txscript.OP_EQUALVERIFY && script[24] == txscript.OP_CHECKSIG:
+		copy(addr[:], script[3:23]) // P2PKH
+	case len(script) == 23 && script[0] == txscript.OP_HASH160 && script[1] == txscript.OP_DATA_20 &&
+		script[22] == txscript.OP_EQUAL:
+		copy(addr[:], script[2:22]) // P2SH
+	case len(script) == 22 && script[0] == txscript.OP_0 && script[1] == txscript.OP_DATA_20,
+		len(script) == 34 && script[0] == txscript.OP_0 && script[1] == txscript.OP_DATA_32,
+		len(script) == 34 && script[0] == txscript.OP_1 && script[1] == txscript.OP_DATA_32:
+		return bch.ConvertWitnessProgramToAddress(script[2:]) // P2WPKH, P2WSH and P2TR
+	default:
+		err = fmt.Errorf("unsupported pkScript")
+	}
+	return
+}
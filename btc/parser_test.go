@@ -0,0 +1,46 @@
+package btc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+func TestExtractOutputInfo(t *testing.T) {
+	hash := "0102030405060708090a0b0c0d0e0f1011121314"
+	program := "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	programBytes, _ := hex.DecodeString(program)
+	h := ripemd160.New()
+	h.Write(programBytes)
+	programHash := hex.EncodeToString(h.Sum(nil))
+	p := NewOutputParser()
+	for _, c := range []struct {
+		typ     string
+		hex     string
+		address string
+	}{
+		{"pubkeyhash", "76a914" + hash + "88ac", hash},
+		{"scripthash", "a914" + hash + "87", hash},
+		{"witness_v0_keyhash", "0014" + hash, hash},
+		{"witness_v0_scripthash", "0020" + program, programHash},
+		{"witness_v1_taproot", "5120" + program, programHash},
+	} {
+		info, err := p.ExtractOutputInfo(&bch.Vout{
+			Vout:  btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{Type: c.typ, Hex: c.hex}},
+			Value: 1.5e8,
+		})
+		require.NoError(t, err, c.typ)
+		addr, _ := hex.DecodeString(c.address)
+		require.Equal(t, bch.PackAddressAndValue(addr, 1.5e8), info, c.typ)
+	}
+
+	_, err := p.ExtractOutputInfo(&bch.Vout{Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{Type: "nulldata", Hex: "6a00"}}})
+	require.Error(t, err)
+	_, err = p.ExtractOutputInfo(&bch.Vout{Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{Type: "witness_v1_taproot", Hex: "5114" + hash}}})
+	require.Error(t, err)
+}
@@ -3,21 +3,27 @@ package chains
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	gethcore "github.com/ethereum/go-ethereum/core"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	modbtypes "github.com/smartbch/moeingdb/types"
 	evmtypes "github.com/smartbch/moeingevm/types"
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/elfinguard/chainlogs/bch"
 	"github.com/elfinguard/chainlogs/config"
 	"github.com/elfinguard/chainlogs/scanner"
+	"github.com/elfinguard/chainlogs/signer"
 	"github.com/elfinguard/chainlogs/store"
+	"github.com/elfinguard/chainlogs/types"
 )
 
 type ChainLogs struct {
@@ -58,18 +64,30 @@ type VirtualChain struct {
 	BlockInterval               int64
 	ChainName                   string
 	ChainID                     [32]byte
+	EvmChainId                  uint64 // eth_chainId, ChainID does not fit in it
 	GenesisMainChainBlockHeight int64
+	MainChainAligned            bool          // one virtual block per main chain block, so adaptors produce the same virtual chain
+	MinConfirmations            int64         // the confirmations of a main chain block before it is aligned
+	Signer                      signer.Signer // signs the logs for chainlogs_getSignedLogs, nil if no operator key
 
 	PrevBlockHash         [32]byte
 	CurrentBlockHeight    int64
 	CurrentBlockTimestamp int64
 	CurrentBlockHash      [32]byte
 
+	PushNotify <-chan bool // signaled when EGTXs or blocks are pushed by the main chain node, nil if only polling
+
 	ticker *time.Ticker
 
-	chainFeed event.Feed // For pub&sub new blocks
-	logsFeed  event.Feed // For pub&sub new logs
-	scope     event.SubscriptionScope
+	alignedHalted bool // the main chain reorganizes an aligned block, the virtual blocks built on it can not be replaced
+
+	healthMtx sync.RWMutex
+	health    types.ScannerHealth
+
+	chainFeed  event.Feed // For pub&sub new blocks
+	logsFeed   event.Feed // For pub&sub new logs
+	rmLogsFeed event.Feed // For pub&sub logs removed by main chain reorgs
+	scope      event.SubscriptionScope
 
 	logger log.Logger
 }
@@ -81,6 +99,10 @@ func (v *VirtualChain) SetLogger(logger log.Logger) {
 
 func (v *VirtualChain) Start() {
 	v.RecoveryFromDB()
+	if v.MainChainAligned {
+		v.startAligned()
+		return
+	}
 	now := time.Now().Unix()
 	if now < v.CurrentBlockTimestamp {
 		panic(fmt.Sprintf("now[%d] <= v.CurrentBlockTimestamp[%d]", now, v.CurrentBlockTimestamp))
@@ -94,9 +116,30 @@ func (v *VirtualChain) Start() {
 	}
 	v.ticker = time.NewTicker(time.Duration(v.BlockInterval) * time.Second)
 	tryGenerateBlockCount := 0
-	for range v.ticker.C {
-		tryGenerateBlockCount++
-		v.GenerateNewBlock(tryGenerateBlockCount%12 == 0)
+	for {
+		select {
+		case <-v.ticker.C:
+			tryGenerateBlockCount++
+			v.GenerateNewBlock(tryGenerateBlockCount%12 == 0)
+		case newBlock := <-v.PushNotify:
+			v.GenerateNewBlock(newBlock)
+		}
+	}
+}
+
+// startAligned polls the main chain blocks, the virtual block timestamps are taken from them
+func (v *VirtualChain) startAligned() {
+	v.GenerateAlignedBlocks()
+	v.ticker = time.NewTicker(time.Duration(v.BlockInterval) * time.Second)
+	for {
+		select {
+		case <-v.ticker.C:
+			v.GenerateAlignedBlocks()
+		case newBlock := <-v.PushNotify:
+			if newBlock {
+				v.GenerateAlignedBlocks()
+			}
+		}
 	}
 }
 
@@ -114,6 +157,14 @@ func (v *VirtualChain) RecoveryFromDB() {
 	if latestScanBlockHeight == 0 {
 		latestScanBlockHeight = v.GenesisMainChainBlockHeight
 	}
+	// the main chain blocks without EGTX scanned after the latest virtual block need not be scanned again, unless
+	// they are orphaned. The aligned virtual blocks are built for every main chain block, so they are always rescanned.
+	if cp := v.Store.GetScanCheckpoint(); !v.MainChainAligned && cp != nil && cp.Height > latestScanBlockHeight {
+		if blk := v.Store.GetMainChainBlock(cp.Height); blk != nil && blk.Hash == cp.Hash {
+			v.logger.Info("resume from scan checkpoint", "height", cp.Height, "hash", hex.EncodeToString(cp.Hash[:]))
+			latestScanBlockHeight = cp.Height
+		}
+	}
 	v.Scanner.SetLatestScanHeight(latestScanBlockHeight)
 	v.logger.Info("recover virtual chain from db", "currentHeight", v.CurrentBlockHeight, "currentBlockTS", v.CurrentBlockTimestamp,
 		"currentBlockHash", hex.EncodeToString(hash[:]), "latestScanBlockHeight", latestScanBlockHeight)
@@ -122,11 +173,18 @@ func (v *VirtualChain) RecoveryFromDB() {
 func (v *VirtualChain) GenerateNewBlock(scanBlock bool) {
 	currentBlockTimestamp := time.Now().Unix()
 	currentBlockHash := sha256.Sum256([]byte(v.ChainName + fmt.Sprintf(":%d", v.CurrentBlockHeight)))
-	txs := v.Scanner.GetNewTxs(v.CurrentBlockHeight+1, currentBlockHash, scanBlock)
-	if len(txs) == 0 && !scanBlock {
+	txs, err := v.Scanner.GetNewTxs(v.CurrentBlockHeight+1, currentBlockHash, scanBlock)
+	v.setScanError(err)
+	v.publishRemovedTxs(v.Scanner.CollectRemovedTxs())
+	if len(txs) == 0 && (!scanBlock || err != nil) {
 		v.logger.Debug("EGTX not found in this round")
 		return
 	}
+	v.packBlock(currentBlockHash, currentBlockTimestamp, txs)
+}
+
+// packBlock builds the next virtual block with the EGTXs collected from the mempool or the main chain blocks
+func (v *VirtualChain) packBlock(currentBlockHash [32]byte, currentBlockTimestamp int64, txs []modbtypes.Tx) {
 	v.CurrentBlockHeight++
 	v.CurrentBlockTimestamp = currentBlockTimestamp
 	v.CurrentBlockHash = currentBlockHash
@@ -141,28 +199,138 @@ func (v *VirtualChain) GenerateNewBlock(scanBlock bool) {
 		Size:      v.CurrentBlockTimestamp,
 		GasUsed:   uint64(v.Scanner.GetLatestScanHeight()), // using gasUsed to store latest scanned mainnet block height
 	}
+	v.addBlock(&evmBlk, txs)
+}
+
+// GenerateAlignedBlocks builds a virtual block for each main chain block with enough confirmations. The block hashes
+// and timestamps are derived from the main chain blocks, so all the adaptors produce byte-identical virtual blocks.
+func (v *VirtualChain) GenerateAlignedBlocks() {
+	if v.alignedHalted {
+		return
+	}
+	for {
+		mainChainBlk, err := v.Scanner.GetMainChainBlockTxs(v.CurrentBlockHeight+1, v.MinConfirmations, v.deriveBlockHash)
+		v.setScanError(err)
+		var reorgErr *types.ReorgTooDeepError
+		if errors.As(err, &reorgErr) {
+			// the scanner stays unhealthy with this error, the db must be rebuilt with more -minConfirmations
+			v.logger.Error("halt aligned block production", "error", err)
+			v.alignedHalted = true
+			return
+		}
+		if mainChainBlk == nil {
+			return
+		}
+		v.CurrentBlockHeight++
+		v.CurrentBlockTimestamp = mainChainBlk.Time
+		v.PrevBlockHash = v.deriveBlockHash(mainChainBlk.ParentHash)
+		v.CurrentBlockHash = v.deriveBlockHash(mainChainBlk.Hash)
+		evmBlk := evmtypes.Block{
+			Number:     v.CurrentBlockHeight,
+			Hash:       v.CurrentBlockHash,
+			ParentHash: v.PrevBlockHash,
+			Timestamp:  v.CurrentBlockTimestamp,
+			Size:       v.CurrentBlockTimestamp,
+			GasUsed:    uint64(mainChainBlk.Height),
+		}
+		v.addBlock(&evmBlk, mainChainBlk.Txs)
+	}
+}
+
+// deriveBlockHash maps a main chain block to its virtual block in the main-chain-aligned mode
+func (v *VirtualChain) deriveBlockHash(mainChainBlockHash [32]byte) [32]byte {
+	return sha256.Sum256(append([]byte(v.ChainName+":"), mainChainBlockHash[:]...))
+}
+
+func (v *VirtualChain) addBlock(evmBlk *evmtypes.Block, txs []modbtypes.Tx) {
 	for _, tx := range txs {
 		evmBlk.Transactions = append(evmBlk.Transactions, tx.HashId)
 	}
+	evmBlk.LogsBloom = types.CreateBloom(txs)
 	blkInfo, err := evmBlk.MarshalMsg(nil)
 	if err != nil {
 		panic(err)
 	}
 	blk := modbtypes.Block{
-		Height:    v.CurrentBlockHeight,
-		BlockHash: v.CurrentBlockHash,
+		Height:    evmBlk.Number,
+		BlockHash: evmBlk.Hash,
 		BlockInfo: blkInfo,
 		TxList:    txs,
 	}
 	v.Store.AddBlock(&blk)
+	// wait until the block is indexed, so the block and its txs can be queried once it is published
+	v.Store.AddBlock(nil)
 	v.publishNewBlock(&blk)
-	v.logger.Info("generate new block", "height", v.CurrentBlockHeight, "txs", len(txs), "blockHash", hex.EncodeToString(v.CurrentBlockHash[:]))
+	v.logger.Info("generate new block", "height", blk.Height, "txs", len(txs), "blockHash", hex.EncodeToString(blk.BlockHash[:]))
+}
+
+// setScanError keeps the chain running when the scan fails, RPC reports the scanner as unhealthy until it recovers
+func (v *VirtualChain) setScanError(err error) {
+	v.healthMtx.Lock()
+	defer v.healthMtx.Unlock()
+	if err == nil {
+		if !v.health.Healthy {
+			if v.health.Error != "" {
+				v.logger.Info("scanner recovered", "unhealthySince", v.health.Since)
+			}
+			v.health = types.ScannerHealth{Healthy: true}
+		}
+		return
+	}
+	v.logger.Error("scan failed", "error", err)
+	if v.health.Healthy || v.health.Since == 0 {
+		v.health.Since = time.Now().Unix()
+	}
+	v.health.Healthy = false
+	v.health.Error = err.Error()
+}
+
+// ScannerHealth is healthy and starting before the first scan finishes
+func (v *VirtualChain) ScannerHealth() types.ScannerHealth {
+	v.healthMtx.RLock()
+	defer v.healthMtx.RUnlock()
+	if v.health == (types.ScannerHealth{}) {
+		return types.ScannerHealth{Healthy: true, Starting: true}
+	}
+	return v.health
+}
+
+func (v *VirtualChain) GetQuarantinedTxs() []types.QuarantinedTx {
+	return v.Scanner.GetQuarantinedTxs()
+}
+
+func (v *VirtualChain) GetDroppedTxs() []types.DroppedTx {
+	return v.Scanner.GetDroppedTxs()
+}
+
+func (v *VirtualChain) GetSkippedBlocks() []types.SkippedBlock {
+	return v.Scanner.GetSkippedBlocks()
+}
+
+func (v *VirtualChain) PrevoutStats() types.PrevoutStats {
+	return v.Scanner.PrevoutStats()
 }
 
 func (v *VirtualChain) GetConfirmations(txHash [32]byte) int32 {
 	return v.Scanner.GetConfirmations(txHash)
 }
 
+func (v *VirtualChain) GetTxProof(txHash [32]byte, fromHeight int64, count int) (*types.TxProof, error) {
+	return v.Scanner.GetTxProof(txHash, fromHeight, count)
+}
+
+func (v *VirtualChain) GetSourceTx(txHash [32]byte) (*types.SourceTx, error) {
+	return v.Scanner.GetSourceTx(txHash)
+}
+
+func (v *VirtualChain) GetScanStatus() (types.ScanStatus, error) {
+	return v.Scanner.GetScanStatus()
+}
+
+func (v *VirtualChain) MainChainEndpoints() []bch.EndpointStatus {
+	return v.Scanner.MainChainEndpoints()
+}
+
 func (v *VirtualChain) SubscribeChainEvent(ch chan<- evmtypes.ChainEvent) event.Subscription {
 	return v.scope.Track(v.chainFeed.Subscribe(ch))
 }
@@ -171,6 +339,28 @@ func (v *VirtualChain) SubscribeLogsEvent(ch chan<- []*gethtypes.Log) event.Subs
 	return v.scope.Track(v.logsFeed.Subscribe(ch))
 }
 
+func (v *VirtualChain) SubscribeRemovedLogsEvent(ch chan<- gethcore.RemovedLogsEvent) event.Subscription {
+	return v.scope.Track(v.rmLogsFeed.Subscribe(ch))
+}
+
+func (v *VirtualChain) publishRemovedTxs(txHashes [][32]byte) {
+	var logs []*gethtypes.Log
+	for _, txHash := range txHashes {
+		tx, _, err := v.Store.GetTxByHash(txHash)
+		if err != nil {
+			continue
+		}
+		for _, l := range evmtypes.ToGethLogs(tx.Logs) {
+			l.Removed = true
+			logs = append(logs, l)
+		}
+	}
+	if len(logs) > 0 {
+		v.logger.Info("remove logs", "txs", len(txHashes), "logs", len(logs))
+		v.rmLogsFeed.Send(gethcore.RemovedLogsEvent{Logs: logs})
+	}
+}
+
 func (v *VirtualChain) publishNewBlock(mdbBlock *modbtypes.Block) {
 	if mdbBlock == nil {
 		return
@@ -0,0 +1,86 @@
+package chains
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// backfillProgressInterval is how often the backfill progress is logged
+const backfillProgressInterval = 10 * time.Second
+
+// Backfill scans the main chain blocks from fromHeight to toHeight into virtual blocks, one virtual block for each
+// main chain block with EGTXs, so the blocks are not capped by MaxTxsInBlock. The mempool is not checked.
+// It resumes after the latest scanned block if it is already in the range, and stops when stop is closed.
+// A range behind the blocks scanned by the db is refused unless it is scanned already.
+func (v *VirtualChain) Backfill(fromHeight, toHeight int64, stop <-chan struct{}) error {
+	if v.MainChainAligned {
+		return errors.New("no need to backfill in main-chain-aligned mode, every main chain block is scanned")
+	}
+	if fromHeight <= 0 || fromHeight > toHeight {
+		return fmt.Errorf("invalid backfill range [%d, %d]", fromHeight, toHeight)
+	}
+	v.RecoveryFromDB()
+	scanHeight := v.Scanner.GetLatestScanHeight()
+	if v.Store.GetMainChainBlock(scanHeight) == nil {
+		// no main chain block is scanned into the db yet, its genesis height does not matter
+		scanHeight = 0
+	}
+	// the blocks are scanned in order, so the range is scanned if its first block is
+	if scanHeight >= fromHeight && v.Store.GetMainChainBlock(fromHeight) == nil {
+		return fmt.Errorf("backfill range [%d, %d] is behind the db's scan height %d but it is not scanned, use a new db",
+			fromHeight, toHeight, scanHeight)
+	}
+	if scanHeight >= toHeight {
+		v.logger.Info("backfill already done", "latestScanBlockHeight", scanHeight)
+		return nil
+	}
+	if scanHeight >= fromHeight {
+		v.logger.Info("resume backfill", "latestScanBlockHeight", scanHeight)
+	} else {
+		if scanHeight+1 < fromHeight && v.CurrentBlockHeight != 0 {
+			v.logger.Info("skip main chain blocks, their EGTXs are not indexed", "from", scanHeight+1, "to", fromHeight-1)
+		}
+		v.Scanner.SetLatestScanHeight(fromHeight - 1)
+	}
+	startHeight, startTime := v.Scanner.GetLatestScanHeight(), time.Now()
+	lastLogTime := startTime
+	egtxCount := 0
+	for v.Scanner.GetLatestScanHeight() < toHeight {
+		select {
+		case <-stop:
+			v.logger.Info("backfill interrupted", "latestScanBlockHeight", v.Scanner.GetLatestScanHeight())
+			return nil
+		default:
+		}
+		currentBlockHash := sha256.Sum256([]byte(v.ChainName + fmt.Sprintf(":%d", v.CurrentBlockHeight)))
+		txs, err := v.Scanner.GetBlockTxs(v.CurrentBlockHeight+1, currentBlockHash, v.Scanner.GetLatestScanHeight()+1)
+		if err != nil {
+			return err
+		}
+		if len(txs) != 0 {
+			v.packBlock(currentBlockHash, time.Now().Unix(), txs)
+			egtxCount += len(txs)
+		}
+		if time.Since(lastLogTime) >= backfillProgressInterval {
+			lastLogTime = time.Now()
+			v.logBackfillProgress(startHeight, toHeight, startTime, egtxCount)
+		}
+	}
+	v.logBackfillProgress(startHeight, toHeight, startTime, egtxCount)
+	return nil
+}
+
+func (v *VirtualChain) logBackfillProgress(startHeight, toHeight int64, startTime time.Time, egtxCount int) {
+	scanHeight := v.Scanner.GetLatestScanHeight()
+	scanned, total := scanHeight-startHeight, toHeight-startHeight
+	elapsed := time.Since(startTime)
+	var eta time.Duration
+	if scanned > 0 {
+		eta = elapsed / time.Duration(scanned) * time.Duration(total-scanned)
+	}
+	v.logger.Info("backfill progress", "height", scanHeight, "to", toHeight,
+		"progress", fmt.Sprintf("%.2f%%", float64(scanned)*100/float64(total)), "egtxs", egtxCount,
+		"elapsed", elapsed.Round(time.Second), "eta", eta.Round(time.Second))
+}
@@ -3,6 +3,7 @@ package chains
 import (
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/elfinguard/chainlogs/bch"
 	"github.com/elfinguard/chainlogs/config"
 	"github.com/elfinguard/chainlogs/scanner"
 	"github.com/elfinguard/chainlogs/store"
@@ -12,13 +13,25 @@ func NewBchVirtualChain(cfg *config.ChainConfig, store store.IStore, logger log.
 	if len(cfg.ClientUrls) == 0 {
 		return nil
 	}
+	params, err := bch.GetNetParams(cfg.Network)
+	if err != nil {
+		panic(err)
+	}
+	s := scanner.NewBchScanner(store, params, cfg.ClientUrls, cfg.MaxTxsInBlock, logger.With("module", "scanner"))
+	useQuorum(cfg, s, bch.NewEndpoints(cfg.ClientUrls, logger), logger.With("module", "quorum"))
+	usePrevoutResolver(cfg, s)
 	c := VirtualChain{
-		Scanner:                     scanner.NewBchScanner(store, cfg.ClientUrls[0], cfg.MaxTxsInBlock, logger.With("module", "scanner")),
+		Scanner:                     s,
+		PushNotify:                  listenZmq(cfg, s),
 		Store:                       store,
 		BlockInterval:               cfg.BlockInterval,
 		ChainName:                   cfg.ChainName,
 		ChainID:                     cfg.ChainId,
+		EvmChainId:                  cfg.EvmChainId,
 		GenesisMainChainBlockHeight: cfg.GenesisMainChainBlockHeight,
+		MainChainAligned:            cfg.MainChainAligned,
+		MinConfirmations:            cfg.MinConfirmations,
+		Signer:                      loadSigner(cfg),
 		logger:                      logger,
 	}
 	return &c
@@ -0,0 +1,34 @@
+package chains
+
+import (
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/config"
+	"github.com/elfinguard/chainlogs/scanner"
+	"github.com/elfinguard/chainlogs/store"
+)
+
+func NewBtcVirtualChain(cfg *config.ChainConfig, store store.IStore, logger log.Logger) *VirtualChain {
+	if len(cfg.ClientUrls) == 0 {
+		return nil
+	}
+	s := scanner.NewBtcScanner(store, cfg.ClientUrls, cfg.MaxTxsInBlock, logger.With("module", "scanner"))
+	useQuorum(cfg, s.BchScanner, bch.NewEndpoints(cfg.ClientUrls, logger), logger.With("module", "quorum"))
+	usePrevoutResolver(cfg, s.BchScanner)
+	c := VirtualChain{
+		Scanner:                     s,
+		PushNotify:                  listenZmq(cfg, s.BchScanner),
+		Store:                       store,
+		BlockInterval:               cfg.BlockInterval,
+		ChainName:                   cfg.ChainName,
+		ChainID:                     cfg.ChainId,
+		EvmChainId:                  cfg.EvmChainId,
+		GenesisMainChainBlockHeight: cfg.GenesisMainChainBlockHeight,
+		MainChainAligned:            cfg.MainChainAligned,
+		MinConfirmations:            cfg.MinConfirmations,
+		Signer:                      loadSigner(cfg),
+		logger:                      logger,
+	}
+	return &c
+}
@@ -0,0 +1,44 @@
+package chains
+
+import (
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/config"
+	"github.com/elfinguard/chainlogs/scanner"
+	"github.com/elfinguard/chainlogs/signer"
+)
+
+// useQuorum makes the scanner cross-check the txs and blocks among all the main chain nodes, if cfg.Quorum is set
+func useQuorum(cfg *config.ChainConfig, s *scanner.BchScanner, endpoints []bch.Endpoint, logger log.Logger) {
+	if cfg.Quorum == 0 {
+		return
+	}
+	s.Client = bch.NewQuorumClient(s.Client, endpoints, cfg.Quorum, cfg.HaltOnDisagreement, 10, 999, logger)
+}
+
+// usePrevoutResolver resolves the outputs spent by EGTXs with cfg.PrevoutWorkers and cfg.PrevoutCacheSize,
+// it must be called after s.Client is set
+func usePrevoutResolver(cfg *config.ChainConfig, s *scanner.BchScanner) {
+	s.Prevouts = scanner.NewPrevoutResolver(s.Client, cfg.PrevoutWorkers, cfg.PrevoutCacheSize)
+}
+
+// listenZmq makes the scanner take new EGTXs and blocks from ZMQ notifications, if cfg.ZmqAddr is set
+func listenZmq(cfg *config.ChainConfig, s *scanner.BchScanner) <-chan bool {
+	if cfg.ZmqAddr == "" {
+		return nil
+	}
+	return s.ListenZmq(cfg.ZmqAddr)
+}
+
+// loadSigner loads the operator key from cfg.OperatorKeyFile, it returns nil if the file is not set
+func loadSigner(cfg *config.ChainConfig) signer.Signer {
+	if cfg.OperatorKeyFile == "" {
+		return nil
+	}
+	s, err := signer.NewKeyFileSigner(cfg.OperatorKeyFile)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
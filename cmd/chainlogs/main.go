@@ -4,23 +4,66 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/tendermint/tendermint/libs/cli/flags"
 	"github.com/tendermint/tendermint/libs/log"
+	tmservice "github.com/tendermint/tendermint/libs/service"
 
 	"github.com/elfinguard/chainlogs/chains"
 	"github.com/elfinguard/chainlogs/config"
 	"github.com/elfinguard/chainlogs/rpc"
+	"github.com/elfinguard/chainlogs/scanner"
 	"github.com/elfinguard/chainlogs/store"
 )
 
+type chainFlags struct {
+	shortName                   string
+	clientInfo                  string
+	genesisMainChainBlockHeight int64
+	rpcAddrs                    string
+	zmqAddr                     string
+	newChainConfig              func(config *config.Config, network string, clientUrls []string, genesisMainChainBlockHeight int64) *config.ChainConfig
+	newVirtualChain             func(cfg *config.ChainConfig, store store.IStore, logger log.Logger) *chains.VirtualChain
+}
+
 func main() {
-	var bchClientInfo string
-	flag.StringVar(&bchClientInfo, "bchClientInfo", bchClientInfo, "bch chain client info, format: url,username,password")
+	// "chainlogs backfill -from H1 -to H2 ..." scans a historical range of main chain blocks and exits
+	backfill := len(os.Args) > 1 && os.Args[1] == "backfill"
+	if backfill {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+	bch := chainFlags{shortName: "bch", newChainConfig: config.NewBchChainConfig, newVirtualChain: chains.NewBchVirtualChain}
+	btc := chainFlags{shortName: "btc", newChainConfig: config.NewBtcChainConfig, newVirtualChain: chains.NewBtcVirtualChain}
+	ltc := chainFlags{shortName: "ltc", newChainConfig: config.NewLtcChainConfig, newVirtualChain: chains.NewLtcVirtualChain}
+	doge := chainFlags{shortName: "doge", newChainConfig: config.NewDogeChainConfig, newVirtualChain: chains.NewDogeVirtualChain}
+	flag.StringVar(&bch.clientInfo, "bchClientInfo", bch.clientInfo, "bch chain client info, format: url,username,password, use \";\" to separate several nodes for failover")
+	flag.StringVar(&btc.clientInfo, "btcClientInfo", btc.clientInfo, "btc chain client info, format: url,username,password, use \";\" to separate several nodes for failover")
+	flag.StringVar(&ltc.clientInfo, "ltcClientInfo", ltc.clientInfo, "ltc chain client info, format: url,username,password, use \";\" to separate several nodes for failover")
+	flag.StringVar(&doge.clientInfo, "dogeClientInfo", doge.clientInfo, "doge chain client info, format: url,username,password, use \";\" to separate several nodes for failover")
+	var network = config.MainNet
+	flag.StringVar(&network, "network", network, "main chain network: mainnet, testnet3, testnet4, chipnet or regtest, a test network is mapped to the test network of every chain, such as doge's testnet")
 	var dbPath string
-	flag.StringVar(&dbPath, "dbPath", dbPath, "db path")
-	var genesisMainChainBlockHeight int64
-	flag.Int64Var(&genesisMainChainBlockHeight, "genesisMainChainBlockHeight", genesisMainChainBlockHeight, "genesis main chain block height which virtual chain scanned from")
+	flag.StringVar(&dbPath, "dbPath", dbPath, "db path, every chain's store is in its sub directory, such as bch")
+	flag.Int64Var(&bch.genesisMainChainBlockHeight, "genesisMainChainBlockHeight", bch.genesisMainChainBlockHeight, "genesis main chain block height which bch virtual chain scanned from")
+	flag.Int64Var(&btc.genesisMainChainBlockHeight, "btcGenesisMainChainBlockHeight", btc.genesisMainChainBlockHeight, "genesis main chain block height which btc virtual chain scanned from")
+	flag.Int64Var(&ltc.genesisMainChainBlockHeight, "ltcGenesisMainChainBlockHeight", ltc.genesisMainChainBlockHeight, "genesis main chain block height which ltc virtual chain scanned from")
+	flag.Int64Var(&doge.genesisMainChainBlockHeight, "dogeGenesisMainChainBlockHeight", doge.genesisMainChainBlockHeight, "genesis main chain block height which doge virtual chain scanned from")
+	var quorum int
+	flag.IntVar(&quorum, "quorum", quorum, "if it is not zero, txs and blocks are accepted only when so many nodes in the client info agree")
+	var haltOnDisagreement bool
+	flag.BoolVar(&haltOnDisagreement, "haltOnDisagreement", haltOnDisagreement, "halt instead of skipping when the nodes do not reach quorum")
+	var mainChainAligned bool
+	flag.BoolVar(&mainChainAligned, "mainChainAligned", mainChainAligned, "build one virtual block per main chain block with only its EGTXs, so independent adaptors produce identical virtual chains. It must be used with a new db")
+	var minConfirmations int64 = config.DefaultMinConfirmations
+	flag.Int64Var(&minConfirmations, "minConfirmations", minConfirmations, "the confirmations of a main chain block before its virtual block is built in main-chain-aligned mode, a deeper main chain reorg halts this mode")
+	var prevoutWorkers = scanner.DefaultPrevoutWorkers
+	flag.IntVar(&prevoutWorkers, "prevoutWorkers", prevoutWorkers, "max concurrent requests to main chain node for the outputs spent by an EGTX")
+	var prevoutCacheSize = scanner.DefaultPrevoutCacheSize
+	flag.IntVar(&prevoutCacheSize, "prevoutCacheSize", prevoutCacheSize, "max cached outputs spent by EGTXs")
+	var operatorKeyFile string
+	flag.StringVar(&operatorKeyFile, "operatorKeyFile", operatorKeyFile, "the file of the hex private key which signs the logs returned by chainlogs_getSignedLogs")
 	var rpcAddr = "tcp://:8545"
 	flag.StringVar(&rpcAddr, "http.addr", rpcAddr, "HTTP-RPC server listening address")
 	var wsAddr = "tcp://:8546"
@@ -29,36 +72,126 @@ func main() {
 	flag.StringVar(&rpcAddrSecure, "https.addr", rpcAddrSecure, "HTTPS-RPC server listening address, use special value \"off\" to disable HTTPS")
 	var wsAddrSecure = "tcp://:9546"
 	flag.StringVar(&wsAddrSecure, "wss.addr", wsAddrSecure, "WSS-RPC server listening address, use special value \"off\" to disable WSS")
+	const rpcAddrsUsage = "dedicated RPC listening addresses of %s virtual chain, format: http,ws,https,wss. If it is empty, the chain is served under the /%s path of the shared addresses"
+	flag.StringVar(&bch.rpcAddrs, "bchRpcAddrs", bch.rpcAddrs, fmt.Sprintf(rpcAddrsUsage, "bch", "bch"))
+	flag.StringVar(&btc.rpcAddrs, "btcRpcAddrs", btc.rpcAddrs, fmt.Sprintf(rpcAddrsUsage, "btc", "btc"))
+	flag.StringVar(&ltc.rpcAddrs, "ltcRpcAddrs", ltc.rpcAddrs, fmt.Sprintf(rpcAddrsUsage, "ltc", "ltc"))
+	flag.StringVar(&doge.rpcAddrs, "dogeRpcAddrs", doge.rpcAddrs, fmt.Sprintf(rpcAddrsUsage, "doge", "doge"))
+	const zmqAddrUsage = "zmqpubrawtx and zmqpubhashblock address of %s node, such as tcp://127.0.0.1:28332. If it is empty, the mempool is polled"
+	flag.StringVar(&bch.zmqAddr, "bchZmqAddr", bch.zmqAddr, fmt.Sprintf(zmqAddrUsage, "bch"))
+	flag.StringVar(&btc.zmqAddr, "btcZmqAddr", btc.zmqAddr, fmt.Sprintf(zmqAddrUsage, "btc"))
+	flag.StringVar(&ltc.zmqAddr, "ltcZmqAddr", ltc.zmqAddr, fmt.Sprintf(zmqAddrUsage, "ltc"))
+	flag.StringVar(&doge.zmqAddr, "dogeZmqAddr", doge.zmqAddr, fmt.Sprintf(zmqAddrUsage, "doge"))
 	var corsDomain = "*"
 	flag.StringVar(&corsDomain, "http.corsdomain", corsDomain, "Comma separated list of domains from which to accept cross origin requests (browser enforced)")
+	var backfillFrom, backfillTo int64
+	flag.Int64Var(&backfillFrom, "from", backfillFrom, "the first main chain block height to scan, used with the backfill command")
+	flag.Int64Var(&backfillTo, "to", backfillTo, "the last main chain block height to scan, used with the backfill command")
 	var logLevel = "info"
 	flag.StringVar(&logLevel, "logLevel", logLevel, "Log level like tendermint format")
 	flag.Parse()
 
 	cfg := config.DefaultConfig()
-	bchChainConfig := config.NewBchChainConfig(&cfg, []string{bchClientInfo}, genesisMainChainBlockHeight)
-	cfg.RegisterChainConfig(bchChainConfig.ChainName, bchChainConfig)
 	defaultRpcEthGetLogsMaxResults := 10000
 
 	logger, err := flags.ParseLogLevel(logLevel, log.NewTMLogger(log.NewSyncWriter(os.Stdout)), "info")
 	if err != nil {
 		panic(err)
 	}
-	s := store.NewChainLogDB(dbPath, defaultRpcEthGetLogsMaxResults, logger.With("module", "db"))
 	a := chains.NewChainLogs(&cfg, logger.With("module", "adapter"))
-	bchVirtualChain := chains.NewBchVirtualChain(bchChainConfig, s, logger.With("module", "vc"))
-	a.RegisterChain(bchChainConfig.ChainName, bchVirtualChain)
-	rpcServer, err := rpc.NewAndStartServer(bchVirtualChain, dbPath, rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, logger.With("module", "rpc"))
-	if err != nil {
-		panic(err)
+	var sharedRoutes []rpc.Route
+	var rpcServers []tmservice.Service
+	for _, c := range []chainFlags{bch, btc, ltc, doge} {
+		if c.clientInfo == "" {
+			continue
+		}
+		chainNetwork, err := config.ChainNetwork(c.shortName, network)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		chainConfig := c.newChainConfig(&cfg, chainNetwork, strings.Split(c.clientInfo, ";"), c.genesisMainChainBlockHeight)
+		chainConfig.Quorum = quorum
+		chainConfig.HaltOnDisagreement = haltOnDisagreement
+		if !backfill {
+			chainConfig.ZmqAddr = c.zmqAddr
+		}
+		chainConfig.PrevoutWorkers = prevoutWorkers
+		chainConfig.PrevoutCacheSize = prevoutCacheSize
+		chainConfig.MainChainAligned = mainChainAligned
+		chainConfig.MinConfirmations = minConfirmations
+		chainConfig.OperatorKeyFile = operatorKeyFile
+		cfg.RegisterChainConfig(chainConfig.ChainName, chainConfig)
+		s := store.NewChainLogDB(filepath.Join(dbPath, chainConfig.ShortName), defaultRpcEthGetLogsMaxResults, logger.With("module", "db", "chain", chainConfig.ShortName))
+		vc := c.newVirtualChain(chainConfig, s, logger.With("module", "vc", "chain", chainConfig.ShortName))
+		a.RegisterChain(chainConfig.ChainName, vc)
+		if backfill {
+			continue
+		}
+		if c.rpcAddrs == "" {
+			sharedRoutes = append(sharedRoutes, rpc.Route{Path: "/" + chainConfig.ShortName, VC: vc})
+			continue
+		}
+		addrs := strings.Split(c.rpcAddrs, ",")
+		if len(addrs) != 4 {
+			panic("invalid rpc addresses of " + chainConfig.ShortName)
+		}
+		rpcServer, err := rpc.NewAndStartServer([]rpc.Route{{Path: "/", VC: vc}}, dbPath, addrs[0], addrs[1], addrs[2], addrs[3],
+			corsDomain, logger.With("module", "rpc", "chain", chainConfig.ShortName))
+		if err != nil {
+			panic(err)
+		}
+		rpcServers = append(rpcServers, rpcServer)
+	}
+	if len(a.Chains) == 0 {
+		panic("no chain client info")
+	}
+	if backfill {
+		runBackfill(a, backfillFrom, backfillTo)
+		return
+	}
+	if len(sharedRoutes) == 1 {
+		// a single chain is served under all the paths, as the earlier versions did
+		sharedRoutes[0].Path = "/"
+	}
+	if len(sharedRoutes) != 0 {
+		rpcServer, err := rpc.NewAndStartServer(sharedRoutes, dbPath, rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, logger.With("module", "rpc"))
+		if err != nil {
+			panic(err)
+		}
+		rpcServers = append(rpcServers, rpcServer)
 	}
 	go chains.TrapSignal(func() {
 		for _, c := range a.Chains {
 			c.Store.Close()
 		}
-		_ = rpcServer.Stop()
+		for _, rpcServer := range rpcServers {
+			_ = rpcServer.Stop()
+		}
 		fmt.Println("exiting...")
 	})
 	a.Run()
 	select {}
 }
+
+// runBackfill scans the main chain blocks in [from, to] without the mempool loop or RPC servers. An interrupted
+// backfill is resumed by running it again with the same db.
+func runBackfill(a *chains.ChainLogs, from, to int64) {
+	if len(a.Chains) != 1 {
+		panic("backfill one chain at a time, the main chain heights differ among chains")
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	go chains.TrapSignal(func() {
+		close(stop)
+		<-done
+		fmt.Println("exiting...")
+	})
+	for _, c := range a.Chains {
+		err := c.Backfill(from, to, stop)
+		c.Store.Close()
+		close(done)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
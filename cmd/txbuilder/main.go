@@ -8,7 +8,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"math"
 	"time"
 
 	gethcmn "github.com/ethereum/go-ethereum/common"
@@ -27,6 +26,7 @@ import (
 
 type Sender struct {
 	mainChainClient *rpcclient.Client
+	params          *chaincfg.Params
 
 	from bchutil.Address
 	wif  *bchutil.WIF
@@ -39,12 +39,19 @@ type Sender struct {
 	otherData       [32]byte
 
 	payTo  bchutil.Address
-	payAmt float64
+	payAmt bch.Amount
 }
 
-func newSender(mainChainClientInfo, wif string) *Sender {
+// unspentUtxo is btcjson.ListUnspentResult with an exact amount
+type unspentUtxo struct {
+	btcjson.ListUnspentResult
+	Amount bch.Amount `json:"amount"`
+}
+
+func newSender(mainChainClientInfo, wif string, params *chaincfg.Params) *Sender {
 	s := Sender{
-		fee: 400,
+		params: params,
+		fee:    400,
 	}
 	_, s.mainChainClient = bch.MakeMainChainClient(mainChainClientInfo)
 	s.initMainChainFields(wif)
@@ -62,8 +69,9 @@ func main() {
 	var payeeEthAddr string
 	var fileId string
 	var payeeBchAddr string
-	var payAmt float64
+	var payAmt bch.Amount
 	var minerFee int64
+	var network string
 
 	flag.BoolVar(&keyGenFlag, "key-gen", false, "gen new key")
 	flag.BoolVar(&listUtxoFlag, "list-utxo", false, "list UTXO")
@@ -76,12 +84,19 @@ func main() {
 	flag.StringVar(&payeeEthAddr, "payee", "0x", "payee's ETH address")
 	flag.StringVar(&fileId, "file-id", "0x", "fileID")
 	flag.StringVar(&payeeBchAddr, "pay-to", "", "payee's BCH address")
-	flag.Float64Var(&payAmt, "pay-amt", 0, "payment amount")
+	flag.Var(&payAmt, "pay-amt", "payment amount")
 	flag.Int64Var(&minerFee, "miner-fee", 400, "miner fee (in satoshi)")
+	flag.StringVar(&network, "network", "mainnet", "main chain network: mainnet, testnet4, chipnet or regtest")
 	flag.Parse()
 
+	params, err := bch.GetNetParams(network)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	if keyGenFlag {
-		generateNewKey()
+		generateNewKey(params)
 		return
 	}
 
@@ -90,7 +105,7 @@ func main() {
 		flag.Usage()
 	}
 
-	s := newSender(mainChainClientInfo, wif)
+	s := newSender(mainChainClientInfo, wif, params)
 	copy(s.contractAddress[:], gethcmn.FromHex(contractEthAddr))
 	copy(s.payer[:], gethcmn.FromHex(payerEthAddr))
 	copy(s.payee[:], gethcmn.FromHex(payeeEthAddr))
@@ -98,7 +113,7 @@ func main() {
 	s.fee = minerFee
 	//fmt.Println(minerFee)
 	if payeeBchAddr != "" {
-		payToAddr, err := bchutil.DecodeAddress(payeeBchAddr, &chaincfg.MainNetParams)
+		payToAddr, err := bchutil.DecodeAddress(payeeBchAddr, params)
 		if err != nil {
 			fmt.Println("can not decode pay-to address:", err.Error())
 			return
@@ -127,12 +142,15 @@ func main() {
 	}
 }
 
-func (s *Sender) listUnspentUtxo(address bchutil.Address) []btcjson.ListUnspentResult {
+func (s *Sender) listUnspentUtxo(address bchutil.Address) []unspentUtxo {
 	fmt.Printf("address: %s\n", address.EncodeAddress())
-	var unspentList []btcjson.ListUnspentResult
-	var err error
+	addresses, _ := json.Marshal([]string{address.EncodeAddress()})
+	var unspentList []unspentUtxo
 	for {
-		unspentList, err = s.mainChainClient.ListUnspentMinMaxAddresses(1, 9999, []bchutil.Address{address})
+		res, err := s.mainChainClient.RawRequest("listunspent", []json.RawMessage{[]byte("1"), []byte("9999"), addresses})
+		if err == nil {
+			err = json.Unmarshal(res, &unspentList)
+		}
 		if err != nil {
 			fmt.Println(err)
 			time.Sleep(10 * time.Second)
@@ -144,7 +162,7 @@ func (s *Sender) listUnspentUtxo(address bchutil.Address) []btcjson.ListUnspentR
 	return unspentList
 }
 
-func (s *Sender) buildAndSendEGTX(unspent btcjson.ListUnspentResult, dryRun bool) (*chainhash.Hash, error) {
+func (s *Sender) buildAndSendEGTX(unspent unspentUtxo, dryRun bool) (*chainhash.Hash, error) {
 	tx, err := s.buildEGTX(unspent)
 	if err != nil {
 		return nil, err
@@ -160,9 +178,9 @@ func (s *Sender) buildAndSendEGTX(unspent btcjson.ListUnspentResult, dryRun bool
 	return s.sendEGTX(tx)
 }
 
-func (s *Sender) buildEGTX(unspent btcjson.ListUnspentResult) (*wire.MsgTx, error) {
-	inAmt := int64(unspent.Amount * 1e8)
-	payAmt := int64(s.payAmt * 1e8)
+func (s *Sender) buildEGTX(unspent unspentUtxo) (*wire.MsgTx, error) {
+	inAmt := int64(unspent.Amount)
+	payAmt := int64(s.payAmt)
 	if inAmt <= payAmt+s.fee {
 		return nil, errors.New("unspent amount not enough")
 	}
@@ -203,7 +221,7 @@ func (s *Sender) buildEGTX(unspent btcjson.ListUnspentResult) (*wire.MsgTx, erro
 	// sign
 	scriptPubkey, _ := hex.DecodeString(unspent.ScriptPubKey)
 	hashType := txscript.SigHashAll | txscript.SigHashForkID
-	sigHash, err := txscript.CalcSignatureHash(scriptPubkey, txscript.NewTxSigHashes(tx), hashType, tx, 0, int64(math.Round(unspent.Amount*1e8)), true)
+	sigHash, err := txscript.CalcSignatureHash(scriptPubkey, txscript.NewTxSigHashes(tx), hashType, tx, 0, inAmt, true)
 	if err != nil {
 		return nil, err
 	}
@@ -240,8 +258,7 @@ func (s *Sender) initMainChainFields(wif string) {
 	}
 	s.wif = w
 	pkhFrom := bchutil.Hash160(w.SerializePubKey())
-	//from, err := bchutil.NewAddressPubKeyHash(pkhFrom, &chaincfg.TestNet3Params)
-	from, err := bchutil.NewAddressPubKeyHash(pkhFrom, &chaincfg.MainNetParams)
+	from, err := bchutil.NewAddressPubKeyHash(pkhFrom, s.params)
 	if err != nil {
 		panic(err)
 	}
@@ -272,16 +289,15 @@ func (s *Sender) buildNullDataScript() []byte {
 	return script
 }
 
-func generateNewKey() {
+func generateNewKey(params *chaincfg.Params) {
 	fmt.Println("generate new key ...")
-	params := &chaincfg.MainNetParams
 	priv, _ := bchec.NewPrivateKey(bchec.S256())
 	wif, err := bchutil.NewWIF(priv, params, false)
 	if err != nil {
 		panic(err)
 	}
 
-	//fmt.Println("network:", params.Name)
+	fmt.Println("network:", params.Name)
 	fmt.Println("key WIF:", wif.String())
 	pkhFrom := bchutil.Hash160(wif.SerializePubKey())
 	from, _ := bchutil.NewAddressPubKeyHash(pkhFrom, params)
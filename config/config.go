@@ -1,5 +1,17 @@
 package config
 
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+const MainNet = "mainnet"
+
+// DefaultMinConfirmations makes an aligned virtual block survive the usual main chain reorgs, a deeper reorg
+// halts the main-chain-aligned mode
+const DefaultMinConfirmations = 6
+
 type Config struct {
 	ChainsSupported map[string]*ChainConfig //chainName => chainConfig
 	ChainPrefix     string
@@ -13,32 +25,112 @@ func DefaultConfig() Config {
 	return c
 }
 
+// RegisterChainConfig panics if the chain's EVM chain id collides with another registered chain's
 func (c *Config) RegisterChainConfig(chainName string, chainConfig *ChainConfig) {
+	for name, other := range c.ChainsSupported {
+		if name != chainName && other.EvmChainId == chainConfig.EvmChainId {
+			panic(fmt.Sprintf("EVM chain id %d of %s collides with %s", chainConfig.EvmChainId, chainName, name))
+		}
+	}
 	c.ChainsSupported[chainName] = chainConfig
 }
 
 type ChainConfig struct {
 	ChainName                   string
+	ShortName                   string // used as the chain's RPC path and store directory, e.g. "bch"
+	Network                     string // mainnet, testnet4, chipnet, regtest, etc.
 	ChainId                     [32]byte
+	EvmChainId                  uint64   // served by eth_chainId and net_version, derived from ChainId
 	ClientUrls                  []string //format is: ip:port,username,password
 	BlockInterval               int64
 	MaxTxsInBlock               int
 	GenesisMainChainBlockHeight int64
+	Quorum                      int    // if it is not zero, txs and blocks are accepted only when so many nodes of ClientUrls agree
+	HaltOnDisagreement          bool   // halt instead of skipping when the nodes do not reach quorum
+	ZmqAddr                     string // the node's zmqpubrawtx and zmqpubhashblock address, polling is used if it is empty
+	PrevoutWorkers              int    // max concurrent requests for the outputs spent by an EGTX, a default is used if it is zero
+	PrevoutCacheSize            int    // max cached outputs spent by EGTXs, a default is used if it is zero
+	MainChainAligned            bool   // build one virtual block per main chain block, instead of packing the mempool
+	MinConfirmations            int64  // the confirmations of a main chain block before it is aligned
+	OperatorKeyFile             string // the hex private key file signing the logs for chainlogs_getSignedLogs, optional
+}
+
+func NewBchChainConfig(config *Config, network string, clientUrls []string, GenesisMainChainBlockHeight int64) *ChainConfig {
+	c := &ChainConfig{
+		ChainName:     config.ChainPrefix + "Bitcoin Cash" + networkSuffix(network),
+		ShortName:     "bch",
+		Network:       network,
+		ClientUrls:    clientUrls,
+		BlockInterval: 5, //5s
+		MaxTxsInBlock: 2000,
+	}
+	c.ChainId = convertChainNameToChainId(c.ChainName)
+	c.EvmChainId = convertChainIdToEvmChainId(c.ChainId)
+	c.GenesisMainChainBlockHeight = GenesisMainChainBlockHeight
+	return c
 }
 
-func NewBchChainConfig(config *Config, clientUrls []string, GenesisMainChainBlockHeight int64) *ChainConfig {
+func NewBtcChainConfig(config *Config, network string, clientUrls []string, GenesisMainChainBlockHeight int64) *ChainConfig {
 	c := &ChainConfig{
-		ChainName:     config.ChainPrefix + "Bitcoin Cash",
+		ChainName:     config.ChainPrefix + "Bitcoin" + networkSuffix(network),
+		ShortName:     "btc",
+		Network:       network,
 		ClientUrls:    clientUrls,
 		BlockInterval: 5, //5s
 		MaxTxsInBlock: 2000,
 	}
 	c.ChainId = convertChainNameToChainId(c.ChainName)
+	c.EvmChainId = convertChainIdToEvmChainId(c.ChainId)
 	c.GenesisMainChainBlockHeight = GenesisMainChainBlockHeight
 	return c
 }
 
+func NewLtcChainConfig(config *Config, network string, clientUrls []string, GenesisMainChainBlockHeight int64) *ChainConfig {
+	c := &ChainConfig{
+		ChainName:     config.ChainPrefix + "Litecoin" + networkSuffix(network),
+		ShortName:     "ltc",
+		Network:       network,
+		ClientUrls:    clientUrls,
+		BlockInterval: 5, //5s
+		MaxTxsInBlock: 2000,
+	}
+	c.ChainId = convertChainNameToChainId(c.ChainName)
+	c.EvmChainId = convertChainIdToEvmChainId(c.ChainId)
+	c.GenesisMainChainBlockHeight = GenesisMainChainBlockHeight
+	return c
+}
+
+func NewDogeChainConfig(config *Config, network string, clientUrls []string, GenesisMainChainBlockHeight int64) *ChainConfig {
+	c := &ChainConfig{
+		ChainName:     config.ChainPrefix + "Dogecoin" + networkSuffix(network),
+		ShortName:     "doge",
+		Network:       network,
+		ClientUrls:    clientUrls,
+		BlockInterval: 5, //5s
+		MaxTxsInBlock: 2000,
+	}
+	c.ChainId = convertChainNameToChainId(c.ChainName)
+	c.EvmChainId = convertChainIdToEvmChainId(c.ChainId)
+	c.GenesisMainChainBlockHeight = GenesisMainChainBlockHeight
+	return c
+}
+
+// networkSuffix makes the chain names and ids of test networks differ from mainnet's
+func networkSuffix(network string) string {
+	if network == MainNet {
+		return ""
+	}
+	return " " + network
+}
+
 func convertChainNameToChainId(name string) (id [32]byte) {
 	copy(id[:], []byte(name))
 	return
 }
+
+// convertChainIdToEvmChainId takes the leading 52 bits of the chain id's hash, as a 32-byte chain id does not fit
+// in eth_chainId. The result is a safe integer in javascript, which is required by wallets.
+func convertChainIdToEvmChainId(id [32]byte) uint64 {
+	h := sha256.Sum256(id[:])
+	return binary.BigEndian.Uint64(h[:8]) >> 12
+}
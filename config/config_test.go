@@ -0,0 +1,56 @@
+package config
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainIdWithNetwork(t *testing.T) {
+	cfg := DefaultConfig()
+	mainnet := NewBchChainConfig(&cfg, MainNet, nil, 0)
+	require.Equal(t, "virtual Bitcoin Cash", mainnet.ChainName)
+	require.Equal(t, convertChainNameToChainId("virtual Bitcoin Cash"), mainnet.ChainId)
+
+	ids := map[[32]byte]string{mainnet.ChainId: MainNet}
+	for _, network := range []string{"testnet4", "chipnet", "regtest"} {
+		c := NewBchChainConfig(&cfg, network, nil, 0)
+		require.Equal(t, network, c.Network)
+		require.Equal(t, "virtual Bitcoin Cash "+network, c.ChainName)
+		_, ok := ids[c.ChainId]
+		require.False(t, ok)
+		ids[c.ChainId] = network
+	}
+}
+
+func TestEvmChainId(t *testing.T) {
+	cfg := DefaultConfig()
+	mainnet := NewBchChainConfig(&cfg, MainNet, nil, 0)
+	// the low 64 bits of the 32-byte chain id are zero padding
+	require.Zero(t, binary.BigEndian.Uint64(mainnet.ChainId[24:]))
+	require.EqualValues(t, 3558223669469782, mainnet.EvmChainId)
+
+	ids := map[uint64]string{}
+	for _, network := range []string{MainNet, "testnet4", "chipnet", "regtest"} {
+		for _, c := range []*ChainConfig{
+			NewBchChainConfig(&cfg, network, nil, 0),
+			NewBtcChainConfig(&cfg, network, nil, 0),
+			NewLtcChainConfig(&cfg, network, nil, 0),
+			NewDogeChainConfig(&cfg, network, nil, 0),
+		} {
+			require.NotZero(t, c.EvmChainId)
+			require.Less(t, c.EvmChainId, uint64(1)<<52)
+			_, ok := ids[c.EvmChainId]
+			require.False(t, ok, c.ChainName)
+			ids[c.EvmChainId] = c.ChainName
+			cfg.RegisterChainConfig(c.ChainName, c)
+		}
+	}
+
+	collided := *mainnet
+	collided.ChainName = "virtual Bitcoin Cash copy"
+	require.Panics(t, func() {
+		cfg.RegisterChainConfig(collided.ChainName, &collided)
+	})
+}
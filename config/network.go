@@ -0,0 +1,21 @@
+package config
+
+import "fmt"
+
+// chainNetworks maps the -network flag to the network names of every chain, as their test networks are named
+// differently, e.g. Litecoin only has testnet4 and Dogecoin calls its test network testnet
+var chainNetworks = map[string]map[string]string{
+	"bch":  {MainNet: MainNet, "testnet3": "testnet3", "testnet4": "testnet4", "chipnet": "chipnet", "regtest": "regtest"},
+	"btc":  {MainNet: MainNet, "testnet3": "testnet3", "testnet4": "testnet4", "chipnet": "testnet4", "regtest": "regtest"},
+	"ltc":  {MainNet: MainNet, "testnet3": "testnet4", "testnet4": "testnet4", "chipnet": "testnet4", "regtest": "regtest"},
+	"doge": {MainNet: MainNet, "testnet3": "testnet", "testnet4": "testnet", "chipnet": "testnet", "regtest": "regtest"},
+}
+
+// ChainNetwork returns the network of the chain named by shortName for the -network flag
+func ChainNetwork(shortName, network string) (string, error) {
+	chainNetwork, ok := chainNetworks[shortName][network]
+	if !ok {
+		return "", fmt.Errorf("network %s is not supported by %s", network, shortName)
+	}
+	return chainNetwork, nil
+}
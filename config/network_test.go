@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainNetwork(t *testing.T) {
+	for _, network := range []string{MainNet, "testnet3", "testnet4", "chipnet", "regtest"} {
+		for shortName := range chainNetworks {
+			_, err := ChainNetwork(shortName, network)
+			require.NoError(t, err)
+		}
+	}
+	network, err := ChainNetwork("doge", "chipnet")
+	require.NoError(t, err)
+	require.Equal(t, "testnet", network)
+	network, err = ChainNetwork("ltc", "chipnet")
+	require.NoError(t, err)
+	require.Equal(t, "testnet4", network)
+	network, err = ChainNetwork("bch", "chipnet")
+	require.NoError(t, err)
+	require.Equal(t, "chipnet", network)
+
+	_, err = ChainNetwork("doge", "signet")
+	require.Error(t, err)
+	_, err = ChainNetwork("xyz", MainNet)
+	require.Error(t, err)
+}
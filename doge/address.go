@@ -0,0 +1,59 @@
+package doge
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gcash/bchutil/base58"
+)
+
+// Params defines the address encodings of a Dogecoin network
+type Params struct {
+	Name             string
+	PubKeyHashAddrID byte // P2PKH addresses, 'D...' on mainnet
+	ScriptHashAddrID byte // P2SH addresses, '9...' or 'A...' on mainnet
+}
+
+var MainNetParams = Params{
+	Name:             "mainnet",
+	PubKeyHashAddrID: 0x1e,
+	ScriptHashAddrID: 0x16,
+}
+
+var TestNetParams = Params{
+	Name:             "testnet",
+	PubKeyHashAddrID: 0x71,
+	ScriptHashAddrID: 0xc4,
+}
+
+var RegTestParams = Params{
+	Name:             "regtest",
+	PubKeyHashAddrID: 0x6f,
+	ScriptHashAddrID: 0xc4,
+}
+
+// GetNetParams returns the params of a Dogecoin network
+func GetNetParams(network string) (*Params, error) {
+	for _, params := range []*Params{&MainNetParams, &TestNetParams, &RegTestParams} {
+		if params.Name == network {
+			return params, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported network: %s", network)
+}
+
+// DecodeAddress returns the 20-byte hash of a P2PKH or P2SH Dogecoin address
+func DecodeAddress(addr string, params *Params) (hash [20]byte, err error) {
+	decoded, version, err := base58.CheckDecode(addr)
+	if err != nil {
+		return hash, fmt.Errorf("failed to decode address: %w", err)
+	}
+	if version != params.PubKeyHashAddrID && version != params.ScriptHashAddrID {
+		return hash, fmt.Errorf("unknown address version: %#x", version)
+	}
+	if len(decoded) != 20 {
+		return hash, errors.New("invalid address length")
+	}
+	copy(hash[:], decoded)
+	return hash, nil
+}
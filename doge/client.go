@@ -0,0 +1,113 @@
+package doge
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/rpcclient"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+var _ bch.IBchClient = &Client{}
+
+// Client talks to a Dogecoin Core node. Its getblock RPC only accepts a boolean verbose param,
+// so GetBlockVerboseTx fetches the block's txids first and then the txs one by one,
+// which requires the node to run with -txindex. Other RPCs are the same as Bitcoin Cash nodes.
+type Client struct {
+	*bch.RetryableClient
+	client   *rpcclient.Client
+	delay    int64 // sleep delay second when retry
+	maxRetry int
+	logger   log.Logger
+}
+
+func NewClient(mainChainClientInfo string, delayTime int64, maxRetry int, logger log.Logger) *Client {
+	_, client := bch.MakeMainChainClient(mainChainClientInfo)
+	return &Client{
+		RetryableClient: bch.NewRetryableClient(mainChainClientInfo, delayTime, maxRetry, logger),
+		client:          client,
+		delay:           delayTime,
+		maxRetry:        maxRetry,
+		logger:          logger,
+	}
+}
+
+// NewMultiClient makes a bch.MultiClient whose endpoints are Dogecoin clients in the format of url,username,password
+func NewMultiClient(mainChainClientInfos []string, delayTime int64, maxRetry int, logger log.Logger) *bch.MultiClient {
+	return bch.NewMultiClient(NewEndpoints(mainChainClientInfos, logger), time.Minute, delayTime, maxRetry, logger)
+}
+
+// NewEndpoints makes Dogecoin clients which do not retry, the callers retry on the other endpoints
+func NewEndpoints(mainChainClientInfos []string, logger log.Logger) []bch.Endpoint {
+	endpoints := make([]bch.Endpoint, len(mainChainClientInfos))
+	for i, info := range mainChainClientInfos {
+		endpoints[i] = bch.Endpoint{
+			Url:    bch.ClientInfoUrl(info),
+			Client: NewClient(info, 0, 1, logger),
+		}
+	}
+	return endpoints
+}
+
+func (c *Client) GetBlockVerboseTx(blockHash *chainhash.Hash) (res *bch.GetBlockVerboseTxResult, err error) {
+	var blk *btcjson.GetBlockVerboseResult
+	for i := 0; i < c.maxRetry; i++ {
+		blk, err = c.getBlockVerbose(blockHash)
+		if err == nil {
+			c.logger.Debug("getBlockVerbose", "blockHash", blockHash, "error", err)
+			break
+		}
+		time.Sleep(time.Duration(c.delay) * time.Second)
+	}
+	if err != nil {
+		return
+	}
+	res = &bch.GetBlockVerboseTxResult{GetBlockVerboseTxResult: btcjson.GetBlockVerboseTxResult{
+		Hash:          blk.Hash,
+		Confirmations: blk.Confirmations,
+		Size:          blk.Size,
+		Height:        blk.Height,
+		Version:       blk.Version,
+		VersionHex:    blk.VersionHex,
+		MerkleRoot:    blk.MerkleRoot,
+		Time:          blk.Time,
+		Nonce:         blk.Nonce,
+		Bits:          blk.Bits,
+		Difficulty:    blk.Difficulty,
+		PreviousHash:  blk.PreviousHash,
+		NextHash:      blk.NextHash,
+	}}
+	for _, txid := range blk.Tx {
+		txHash, err := chainhash.NewHashFromStr(txid)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.GetRawTransactionVerbose(txHash)
+		if err != nil {
+			return nil, err
+		}
+		res.Tx = append(res.Tx, *tx)
+	}
+	return
+}
+
+func (c *Client) getBlockVerbose(blockHash *chainhash.Hash) (*btcjson.GetBlockVerboseResult, error) {
+	hash, err := json.Marshal(blockHash.String())
+	if err != nil {
+		return nil, err
+	}
+	raw, err := c.client.RawRequest("getblock", []json.RawMessage{hash, json.RawMessage("true")})
+	if err != nil {
+		return nil, err
+	}
+	var blk btcjson.GetBlockVerboseResult
+	err = json.Unmarshal(raw, &blk)
+	if err != nil {
+		return nil, err
+	}
+	return &blk, nil
+}
@@ -0,0 +1,59 @@
+package doge
+
+import (
+	"fmt"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchutil"
+	"github.com/holiman/uint256"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+// MaxMoney is the largest amount of a Dogecoin output allowed by consensus, in koinu (1e-8 DOGE)
+const MaxMoney = 10_000_000_000 * bchutil.SatoshiPerBitcoin
+
+// PackAddressAndValue packs a 20-byte address and an amount into one word of EGTX log data.
+// Like other chains, the amount is stored in the lower 12 bytes as wei (1 koinu = 1e10 wei).
+// Dogecoin's supply is uncapped, but an output never exceeds MaxMoney, whose wei value (1e28) is
+// below 2^96, so amounts out of this range are rejected instead of being truncated.
+func PackAddressAndValue(address []byte, value bch.Amount) (info [32]byte, err error) {
+	if value < 0 || value > MaxMoney {
+		err = fmt.Errorf("amount out of range: %d", value)
+		return
+	}
+	copy(info[:20], address)
+	wei := uint256.NewInt(0).Mul(uint256.NewInt(uint64(value)), uint256.NewInt(1e10)).Bytes20()
+	copy(info[20:], wei[8:])
+	return
+}
+
+// OutputParser extracts the address info from the P2PKH and P2SH outputs of Dogecoin transactions
+type OutputParser struct {
+	Params *Params
+}
+
+func NewOutputParser(params *Params) OutputParser {
+	return OutputParser{Params: params}
+}
+
+func (p OutputParser) IsAddressOutput(pkScript *btcjson.ScriptPubKeyResult) bool {
+	return pkScript.Type == "pubkeyhash" || pkScript.Type == "scripthash"
+}
+
+func (p OutputParser) ExtractOutputInfo(vout *bch.Vout) (info [32]byte, err error) {
+	if !p.IsAddressOutput(&vout.ScriptPubKey) {
+		err = fmt.Errorf("invalid pkScript")
+		return
+	}
+	if len(vout.ScriptPubKey.Addresses) != 1 {
+		err = types.PubkeyScriptAddressNumInvalid
+		return
+	}
+	addr, err := DecodeAddress(vout.ScriptPubKey.Addresses[0], p.Params)
+	if err != nil {
+		return
+	}
+	return PackAddressAndValue(addr[:], vout.Value)
+}
@@ -0,0 +1,70 @@
+package doge
+
+import (
+	"testing"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchutil/base58"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+func TestDecodeAddress(t *testing.T) {
+	hash := [20]byte{0x01, 0x02, 0x03}
+	addr := base58.CheckEncode(hash[:], MainNetParams.PubKeyHashAddrID)
+	require.Equal(t, byte('D'), addr[0])
+	decoded, err := DecodeAddress(addr, &MainNetParams)
+	require.NoError(t, err)
+	require.Equal(t, hash, decoded)
+	decoded, err = DecodeAddress(base58.CheckEncode(hash[:], MainNetParams.ScriptHashAddrID), &MainNetParams)
+	require.NoError(t, err)
+	require.Equal(t, hash, decoded)
+
+	// bitcoin address
+	_, err = DecodeAddress(base58.CheckEncode(hash[:], 0x00), &MainNetParams)
+	require.Error(t, err)
+	_, err = DecodeAddress(base58.CheckEncode(hash[:], TestNetParams.PubKeyHashAddrID), &MainNetParams)
+	require.Error(t, err)
+}
+
+func TestPackAddressAndValue(t *testing.T) {
+	addr := [20]byte{0x01}
+	for _, c := range []struct {
+		value string
+		koinu uint64
+	}{
+		{"0.00000001", 1},
+		{"1.1", 110_000_000},
+		{"12345678.12345678", 1_234_567_812_345_678},
+		{"8999999999.99999999", 899_999_999_999_999_999}, // float64 can not hold it
+		{"10000000000", 1_000_000_000_000_000_000},       // MaxMoney
+	} {
+		value, err := bch.ParseAmount(c.value)
+		require.NoError(t, err)
+		info, err := PackAddressAndValue(addr[:], value)
+		require.NoError(t, err)
+		require.Equal(t, addr[:], info[:20])
+		wei := uint256.NewInt(0).SetBytes(info[20:])
+		require.Equal(t, uint256.NewInt(0).Mul(uint256.NewInt(c.koinu), uint256.NewInt(1e10)), wei)
+	}
+	_, err := PackAddressAndValue(addr[:], MaxMoney+1)
+	require.Error(t, err)
+	_, err = PackAddressAndValue(addr[:], -1)
+	require.Error(t, err)
+
+	p := NewOutputParser(&MainNetParams)
+	info, err := p.ExtractOutputInfo(&bch.Vout{
+		Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
+			Type:      "pubkeyhash",
+			Addresses: []string{base58.CheckEncode(addr[:], MainNetParams.PubKeyHashAddrID)},
+		}},
+		Value: 100e8,
+	})
+	require.NoError(t, err)
+	expected, _ := PackAddressAndValue(addr[:], 100e8)
+	require.Equal(t, expected, info)
+	_, err = p.ExtractOutputInfo(&bch.Vout{Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{Type: "nulldata"}}})
+	require.Error(t, err)
+}
@@ -8,10 +8,12 @@ require (
 	github.com/gcash/bchutil v0.0.0-20210113190856-6ea28dff4000
 	github.com/holiman/uint256 v1.2.1
 	github.com/rs/cors v1.9.0
+	github.com/smartbch/moeingads v0.4.2
 	github.com/smartbch/moeingdb v0.4.3
 	github.com/smartbch/moeingevm v0.4.4
 	github.com/stretchr/testify v1.8.2
 	github.com/tendermint/tendermint v0.34.10
+	golang.org/x/crypto v0.9.0
 )
 
 require (
@@ -74,7 +76,6 @@ require (
 	github.com/rogpeppe/go-internal v1.10.0 // indirect
 	github.com/sasha-s/go-deadlock v0.2.1-0.20190427202633-1595213edefa // indirect
 	github.com/shirou/gopsutil v3.21.5+incompatible // indirect
-	github.com/smartbch/moeingads v0.4.2 // indirect
 	github.com/status-im/keycard-go v0.2.0 // indirect
 	github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7 // indirect
 	github.com/tecbot/gorocksdb v0.0.0-20191217155057-f0fad39f321c // indirect
@@ -82,7 +83,6 @@ require (
 	github.com/tklauser/go-sysconf v0.3.10 // indirect
 	github.com/tklauser/numcpus v0.4.0 // indirect
 	github.com/tyler-smith/go-bip39 v1.1.0 // indirect
-	golang.org/x/crypto v0.9.0 // indirect
 	golang.org/x/exp v0.0.0-20230206171751-46f607a40771 // indirect
 	golang.org/x/net v0.10.0 // indirect
 	golang.org/x/sync v0.1.0 // indirect
@@ -0,0 +1,93 @@
+package ltc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gcash/bchutil/base58"
+	"github.com/gcash/bchutil/bech32"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+// Params defines the address encodings of a Litecoin network
+type Params struct {
+	Name                   string
+	PubKeyHashAddrID       byte   // legacy P2PKH addresses, 'L...' on mainnet
+	ScriptHashAddrID       byte   // P2SH addresses, 'M...' on mainnet
+	LegacyScriptHashAddrID byte   // deprecated P2SH addresses, '3...' on mainnet
+	Bech32HRPSegwit        string // segwit addresses, 'ltc1...' on mainnet
+}
+
+var MainNetParams = Params{
+	Name:                   "mainnet",
+	PubKeyHashAddrID:       0x30,
+	ScriptHashAddrID:       0x32,
+	LegacyScriptHashAddrID: 0x05,
+	Bech32HRPSegwit:        "ltc",
+}
+
+var TestNet4Params = Params{
+	Name:                   "testnet4",
+	PubKeyHashAddrID:       0x6f,
+	ScriptHashAddrID:       0x3a,
+	LegacyScriptHashAddrID: 0xc4,
+	Bech32HRPSegwit:        "tltc",
+}
+
+var RegTestParams = Params{
+	Name:                   "regtest",
+	PubKeyHashAddrID:       0x6f,
+	ScriptHashAddrID:       0x3a,
+	LegacyScriptHashAddrID: 0xc4,
+	Bech32HRPSegwit:        "rltc",
+}
+
+// GetNetParams returns the params of a Litecoin network
+func GetNetParams(network string) (*Params, error) {
+	for _, params := range []*Params{&MainNetParams, &TestNet4Params, &RegTestParams} {
+		if params.Name == network {
+			return params, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported network: %s", network)
+}
+
+// DecodeAddress returns the 20-byte hash of a legacy, P2SH or segwit v0 Litecoin address.
+// P2WSH addresses carry 32-byte programs, which are mapped by bch.ConvertWitnessProgramToAddress.
+func DecodeAddress(addr string, params *Params) (hash [20]byte, err error) {
+	if strings.HasPrefix(strings.ToLower(addr), params.Bech32HRPSegwit+"1") {
+		return decodeSegwitAddress(addr, params)
+	}
+	decoded, version, err := base58.CheckDecode(addr)
+	if err != nil {
+		return hash, fmt.Errorf("failed to decode address: %w", err)
+	}
+	if version != params.PubKeyHashAddrID && version != params.ScriptHashAddrID && version != params.LegacyScriptHashAddrID {
+		return hash, fmt.Errorf("unknown address version: %#x", version)
+	}
+	if len(decoded) != 20 {
+		return hash, errors.New("invalid address length")
+	}
+	copy(hash[:], decoded)
+	return hash, nil
+}
+
+func decodeSegwitAddress(addr string, params *Params) (hash [20]byte, err error) {
+	hrp, data, err := bech32.Decode(addr)
+	if err != nil {
+		return hash, fmt.Errorf("failed to decode address: %w", err)
+	}
+	if hrp != params.Bech32HRPSegwit {
+		return hash, fmt.Errorf("invalid address hrp: %s", hrp)
+	}
+	if len(data) == 0 || data[0] != 0 {
+		return hash, errors.New("unsupported witness version")
+	}
+	program, err := bech32.ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return hash, err
+	}
+	return bch.ConvertWitnessProgramToAddress(program)
+}
@@ -0,0 +1,56 @@
+package ltc
+
+import (
+	"testing"
+
+	"github.com/gcash/bchutil/base58"
+	"github.com/gcash/bchutil/bech32"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ripemd160"
+)
+
+func encodeSegwitAddress(hrp string, program []byte) string {
+	data, _ := bech32.ConvertBits(program, 8, 5, true)
+	addr, _ := bech32.Encode(hrp, append([]byte{0}, data...))
+	return addr
+}
+
+func TestDecodeAddress(t *testing.T) {
+	hash := [20]byte{0x01, 0x02, 0x03}
+	for _, version := range []byte{0x30, 0x32, 0x05} {
+		addr := base58.CheckEncode(hash[:], version)
+		decoded, err := DecodeAddress(addr, &MainNetParams)
+		require.NoError(t, err)
+		require.Equal(t, hash, decoded)
+	}
+	require.Equal(t, byte('L'), base58.CheckEncode(hash[:], 0x30)[0])
+	require.Equal(t, byte('M'), base58.CheckEncode(hash[:], 0x32)[0])
+
+	// bitcoin address
+	_, err := DecodeAddress(base58.CheckEncode(hash[:], 0x00), &MainNetParams)
+	require.Error(t, err)
+	// testnet address on mainnet
+	_, err = DecodeAddress(base58.CheckEncode(hash[:], 0x6f), &MainNetParams)
+	require.Error(t, err)
+
+	// P2WPKH
+	addr := encodeSegwitAddress("ltc", hash[:])
+	require.Equal(t, "ltc1q", addr[:5])
+	decoded, err := DecodeAddress(addr, &MainNetParams)
+	require.NoError(t, err)
+	require.Equal(t, hash, decoded)
+
+	// P2WSH
+	program := [32]byte{0x04, 0x05, 0x06}
+	h := ripemd160.New()
+	h.Write(program[:])
+	decoded, err = DecodeAddress(encodeSegwitAddress("ltc", program[:]), &MainNetParams)
+	require.NoError(t, err)
+	require.Equal(t, h.Sum(nil), decoded[:])
+
+	_, err = DecodeAddress(encodeSegwitAddress("tltc", hash[:]), &MainNetParams)
+	require.Error(t, err)
+	decoded, err = DecodeAddress(encodeSegwitAddress("tltc", hash[:]), &TestNet4Params)
+	require.NoError(t, err)
+	require.Equal(t, hash, decoded)
+}
@@ -0,0 +1,43 @@
+package ltc
+
+import (
+	"fmt"
+
+	"github.com/gcash/bchd/btcjson"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+// OutputParser extracts the address info from the P2PKH, P2SH, P2WPKH and P2WSH outputs of Litecoin transactions
+type OutputParser struct {
+	Params *Params
+}
+
+func NewOutputParser(params *Params) OutputParser {
+	return OutputParser{Params: params}
+}
+
+func (p OutputParser) IsAddressOutput(pkScript *btcjson.ScriptPubKeyResult) bool {
+	switch pkScript.Type {
+	case "pubkeyhash", "scripthash", "witness_v0_keyhash", "witness_v0_scripthash":
+		return true
+	}
+	return false
+}
+
+func (p OutputParser) ExtractOutputInfo(vout *bch.Vout) (info [32]byte, err error) {
+	if !p.IsAddressOutput(&vout.ScriptPubKey) {
+		err = fmt.Errorf("invalid pkScript")
+		return
+	}
+	if len(vout.ScriptPubKey.Addresses) != 1 {
+		err = types.PubkeyScriptAddressNumInvalid
+		return
+	}
+	addr, err := DecodeAddress(vout.ScriptPubKey.Addresses[0], p.Params)
+	if err != nil {
+		return
+	}
+	return bch.PackAddressAndValue(addr[:], vout.Value), nil
+}
@@ -9,15 +9,18 @@ import (
 )
 
 const (
-	namespaceEth = "eth"
-	apiVersion   = "1.0"
+	namespaceEth       = "eth"
+	namespaceNet       = "net"
+	namespaceChainLogs = "chainlogs"
+	apiVersion         = "1.0"
 )
 
-// GetAPIs returns the list of all APIs from the Ethereum namespaces
+// GetAPIs returns the list of all APIs from the Ethereum, net and chainlogs namespaces
 func GetAPIs(backend api.BackendService, logger log.Logger) []rpc.API {
 	logger = logger.With("module", "json-rpc")
 	_ethAPI := newEthAPI(backend, logger)
 	_filterAPI := filters.NewAPI(backend, logger)
+	_chainLogsAPI := newChainLogsAPI(backend, _filterAPI, logger)
 
 	return []rpc.API{
 		{
@@ -32,5 +35,17 @@ func GetAPIs(backend api.BackendService, logger log.Logger) []rpc.API {
 			Service:   _filterAPI,
 			Public:    true,
 		},
+		{
+			Namespace: namespaceNet,
+			Version:   apiVersion,
+			Service:   newNetAPI(backend),
+			Public:    true,
+		},
+		{
+			Namespace: namespaceChainLogs,
+			Version:   apiVersion,
+			Service:   _chainLogsAPI,
+			Public:    true,
+		},
 	}
 }
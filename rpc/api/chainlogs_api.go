@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethfilters "github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	motypes "github.com/smartbch/moeingevm/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/api"
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/rpc/api/filters"
+	"github.com/elfinguard/chainlogs/signer"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+// chainLogsAPI serves the chainlogs specific methods, which are not in the eth namespace
+type chainLogsAPI struct {
+	backend api.BackendService
+	filters filters.PublicFilterAPI
+	events  *filters.EventSystem
+	logger  log.Logger
+}
+
+func newChainLogsAPI(backend api.BackendService, filterAPI filters.PublicFilterAPI, logger log.Logger) *chainLogsAPI {
+	return &chainLogsAPI{
+		backend: backend,
+		filters: filterAPI,
+		events:  filters.NewEventSystem(backend, false),
+		logger:  logger,
+	}
+}
+
+// ChainIdentity returns the 32-byte chain id, the chain's name padded with zeros. eth_chainId is derived from it,
+// and chainlogs_getSignedLogs signs it together with the logs.
+func (api *chainLogsAPI) ChainIdentity() common.Hash {
+	return api.backend.ChainIdentity()
+}
+
+// MainChainEndpoints returns the status of the main chain nodes, the active one is used by the scanner
+func (api *chainLogsAPI) MainChainEndpoints() []bch.EndpointStatus {
+	return api.backend.MainChainEndpoints()
+}
+
+// ScannerHealth is unhealthy if the last scan failed, the virtual chain keeps serving the scanned blocks meanwhile
+func (api *chainLogsAPI) ScannerHealth() types.ScannerHealth {
+	return api.backend.ScannerHealth()
+}
+
+// GetQuarantinedTxs returns the EGTXs skipped by the scanner because they can not be derived
+func (api *chainLogsAPI) GetQuarantinedTxs() []types.QuarantinedTx {
+	return api.backend.QuarantinedTxs()
+}
+
+// GetDroppedTxs returns the unconfirmed EGTXs which left the mempool without being mined, their logs are removed
+func (api *chainLogsAPI) GetDroppedTxs() []types.DroppedTx {
+	return api.backend.DroppedTxs()
+}
+
+// GetSkippedBlocks returns the main chain blocks skipped because the nodes do not reach quorum on them
+func (api *chainLogsAPI) GetSkippedBlocks() []types.SkippedBlock {
+	return api.backend.SkippedBlocks()
+}
+
+// PrevoutStats returns the cache hits and misses of resolving the outputs spent by EGTXs
+func (api *chainLogsAPI) PrevoutStats() types.PrevoutStats {
+	return api.backend.PrevoutStats()
+}
+
+// GetLogProof returns the raw main chain tx of an EGTX, its block header and merkle branch, and the following
+// headers, so the logs can be verified against the main chain's proof-of-work without trusting the adaptor.
+// The optional fromHeight and count page the following headers.
+func (api *chainLogsAPI) GetLogProof(txHash common.Hash, fromHeight *hexutil.Uint64, count *hexutil.Uint) (*types.TxProof, error) {
+	api.logger.Debug("chainlogs_getLogProof")
+	var from int64
+	var n int
+	if fromHeight != nil {
+		from = int64(*fromHeight)
+	}
+	if count != nil {
+		n = int(*count)
+	}
+	return api.backend.LogProof(txHash, from, n)
+}
+
+// GetSourceTx returns the main chain tx of an EGTX, with the outputs it spends and creates
+func (api *chainLogsAPI) GetSourceTx(txHash common.Hash) (*types.SourceTx, error) {
+	api.logger.Debug("chainlogs_getSourceTx")
+	return api.backend.SourceTx(txHash)
+}
+
+// GetConfirmations returns the main chain confirmations of an EGTX, -1 if it is double spent or dropped
+func (api *chainLogsAPI) GetConfirmations(txHash common.Hash) int32 {
+	return api.backend.Confirmations(txHash)
+}
+
+// GetScanStatus returns the latest scanned main chain block, the node's tip and how far the scanner is behind
+func (api *chainLogsAPI) GetScanStatus() (types.ScanStatus, error) {
+	return api.backend.ScanStatus()
+}
+
+// DecodeLog decodes the data of an EGTX log, so clients do not need the ABI of EGTXLogData
+func (api *chainLogsAPI) DecodeLog(l gethtypes.Log) (*DecodedLog, error) {
+	return decodeLog(l.Data)
+}
+
+// GetSignedLogs returns the logs like eth_getLogs, each one is signed by the operator key together with
+// the chain id and its confirmations, so authorizers do not have to trust the RPC endpoint. The logs whose
+// confirmations are unknown are left out
+func (api *chainLogsAPI) GetSignedLogs(crit gethfilters.FilterCriteria) ([]SignedLog, error) {
+	api.logger.Debug("chainlogs_getSignedLogs")
+	s := api.backend.LogSigner()
+	if s == nil {
+		return nil, signer.ErrNoSigner
+	}
+	logs, err := api.filters.GetLogs(crit)
+	if err != nil {
+		return nil, err
+	}
+	chainId := api.backend.ChainIdentity().Big()
+	signedLogs := make([]SignedLog, 0, len(logs))
+	for _, l := range logs {
+		if len(l.Data) < 32 {
+			return nil, fmt.Errorf("log %d of tx %s is not an EGTX log", l.Index, l.TxHash)
+		}
+		// the confirmations are put into the data by the backend, all ones means they are unknown, such as the tx
+		// is dropped, then the log is skipped and the others are still signed
+		c := uint256.NewInt(0).SetBytes32(l.Data[:32])
+		if !c.IsUint64() {
+			continue
+		}
+		digest := signer.LogDigest(chainId, l.TxHash, l.Address, l.Topics, l.Data, c.Uint64())
+		sig, err := s.Sign(digest)
+		if err != nil {
+			return nil, err
+		}
+		signedLogs = append(signedLogs, SignedLog{
+			Log:           l,
+			Confirmations: hexutil.Uint64(c.Uint64()),
+			Signer:        s.Address(),
+			Signature:     sig,
+		})
+	}
+	return signedLogs, nil
+}
+
+// Confirmed is served as chainlogs_subscribe("confirmed", crit). It notifies the new logs matching crit once their
+// txs reach crit.MinConfirmations, and notifies them again with -1 confirmations if the txs disappear because
+// of a double spend or reorg. The confirmations are checked when a new virtual block is built.
+func (api *chainLogsAPI) Confirmed(ctx context.Context, crit ConfirmedCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	var (
+		rpcSub      = notifier.CreateSubscription()
+		matchedLogs = make(chan []*gethtypes.Log)
+		headers     = make(chan *motypes.Header)
+		tracker     = newConfirmationTracker(crit.MinConfirmations)
+	)
+
+	logsSub, err := api.events.SubscribeLogs(ethereum.FilterQuery{Addresses: crit.Addresses, Topics: crit.Topics}, matchedLogs)
+	if err != nil {
+		return nil, err
+	}
+	headersSub := api.events.SubscribeNewHeads(headers)
+
+	go func() {
+		defer logsSub.Unsubscribe()
+		defer headersSub.Unsubscribe()
+		for {
+			var confirmedLogs []ConfirmedLog
+			select {
+			case logs := <-matchedLogs:
+				confirmedLogs = tracker.add(logs)
+			case <-headers:
+				confirmedLogs = tracker.check(api.backend.Confirmations)
+			case <-rpcSub.Err(): // client send an unsubscribe request
+				return
+			case <-notifier.Closed(): // connection dropped
+				return
+			}
+			for _, l := range confirmedLogs {
+				_ = notifier.Notify(rpcSub.ID, l)
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
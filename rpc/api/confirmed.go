@@ -0,0 +1,92 @@
+package api
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// confirmedTrackDepth is how many confirmations beyond the threshold a notified tx is still tracked, in case of a reorg
+const confirmedTrackDepth = 10
+
+type trackedTx struct {
+	logs     []*gethtypes.Log
+	notified bool
+}
+
+// confirmationTracker tracks the logs of a chainlogs_subscribe("confirmed") subscription until their txs are deep enough
+type confirmationTracker struct {
+	minConfirmations int32
+	txs              map[common.Hash]*trackedTx
+}
+
+func newConfirmationTracker(minConfirmations int32) *confirmationTracker {
+	return &confirmationTracker{
+		minConfirmations: minConfirmations,
+		txs:              make(map[common.Hash]*trackedTx),
+	}
+}
+
+// add tracks new logs, the removed ones are returned to be notified with -1 confirmations
+func (t *confirmationTracker) add(logs []*gethtypes.Log) (dropped []ConfirmedLog) {
+	for _, l := range logs {
+		if l.Removed {
+			dropped = append(dropped, t.drop(l.TxHash)...)
+			continue
+		}
+		tx := t.txs[l.TxHash]
+		if tx == nil {
+			tx = &trackedTx{}
+			t.txs[l.TxHash] = tx
+		}
+		tx.logs = append(tx.logs, l)
+	}
+	return
+}
+
+// check gets the confirmations of the tracked txs, and returns the logs reaching the threshold. The confirmations
+// are unknown if they are negative, such as the node fails, so the txs are still tracked. The txs double spent or
+// reorged are dropped by their removed logs passed to add.
+func (t *confirmationTracker) check(confirmations func(txHash common.Hash) int32) (res []ConfirmedLog) {
+	for txHash, tx := range t.txs {
+		c := confirmations(txHash)
+		switch {
+		case c < 0:
+			continue
+		case !tx.notified && c >= t.minConfirmations:
+			tx.notified = true
+			for _, l := range tx.logs {
+				res = append(res, newConfirmedLog(l, c))
+			}
+		case tx.notified && c >= t.minConfirmations+confirmedTrackDepth:
+			delete(t.txs, txHash)
+		}
+	}
+	return
+}
+
+func (t *confirmationTracker) drop(txHash common.Hash) (res []ConfirmedLog) {
+	tx := t.txs[txHash]
+	if tx == nil {
+		return nil
+	}
+	delete(t.txs, txHash)
+	for _, l := range tx.logs {
+		res = append(res, newConfirmedLog(l, -1))
+	}
+	return
+}
+
+// newConfirmedLog puts the confirmations into a copy of the log's data, like the backend does for eth_getLogs
+func newConfirmedLog(l *gethtypes.Log, confirmations int32) ConfirmedLog {
+	cl := *l
+	if len(cl.Data) >= 32 {
+		cl.Data = common.CopyBytes(l.Data)
+		c := uint256.NewInt(uint64(confirmations)).Bytes32()
+		if confirmations < 0 {
+			c = uint256.NewInt(0).SetAllOne().Bytes32()
+		}
+		copy(cl.Data[:32], c[:])
+	}
+	return ConfirmedLog{Log: &cl, Confirmations: confirmations}
+}
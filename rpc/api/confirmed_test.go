@@ -0,0 +1,62 @@
+package api
+
+import (
+	"testing"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmationTracker(t *testing.T) {
+	confirmations := map[gethcmn.Hash]int32{}
+	getConfirmations := func(txHash gethcmn.Hash) int32 {
+		c, ok := confirmations[txHash]
+		if !ok {
+			return -1
+		}
+		return c
+	}
+	tracker := newConfirmationTracker(2)
+	logA := &gethtypes.Log{TxHash: gethcmn.Hash{0xA}, Data: make([]byte, 64)}
+	logB := &gethtypes.Log{TxHash: gethcmn.Hash{0xB}, Data: make([]byte, 64)}
+	logC := &gethtypes.Log{TxHash: gethcmn.Hash{0xC}, Data: make([]byte, 64)}
+	require.Empty(t, tracker.add([]*gethtypes.Log{logA, logB, logC}))
+	confirmations[logA.TxHash] = 0
+	confirmations[logB.TxHash] = 1
+	confirmations[logC.TxHash] = 0
+	require.Empty(t, tracker.check(getConfirmations))
+
+	// B reaches the threshold
+	confirmations[logB.TxHash] = 2
+	res := tracker.check(getConfirmations)
+	require.Len(t, res, 1)
+	require.Equal(t, logB.TxHash, res[0].Log.TxHash)
+	require.EqualValues(t, 2, res[0].Confirmations)
+	require.EqualValues(t, 2, res[0].Log.Data[31])
+	require.EqualValues(t, 0, logB.Data[31])
+	require.Empty(t, tracker.check(getConfirmations))
+
+	// the confirmations of A are unknown, such as the node is down, A is still tracked
+	delete(confirmations, logA.TxHash)
+	require.Empty(t, tracker.check(getConfirmations))
+	require.Len(t, tracker.txs, 3)
+
+	// A is double spent, C is reorged
+	removedA, removedC := *logA, *logC
+	removedA.Removed, removedC.Removed = true, true
+	res = tracker.add([]*gethtypes.Log{&removedA})
+	require.Len(t, res, 1)
+	require.Equal(t, logA.TxHash, res[0].Log.TxHash)
+	require.EqualValues(t, -1, res[0].Confirmations)
+	require.EqualValues(t, 0xff, res[0].Log.Data[0])
+	res = tracker.add([]*gethtypes.Log{&removedC})
+	require.Len(t, res, 1)
+	require.EqualValues(t, -1, res[0].Confirmations)
+	require.Len(t, tracker.txs, 1)
+
+	// B is deep enough
+	confirmations[logB.TxHash] = 2 + confirmedTrackDepth
+	require.Empty(t, tracker.check(getConfirmations))
+	require.Empty(t, tracker.txs)
+}
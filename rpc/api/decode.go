@@ -0,0 +1,74 @@
+package api
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+// nftCapabilities is indexed by the second byte of NftCommitmentLengthAndHead
+var nftCapabilities = []string{"", "none", "mutable", "minting"}
+
+func decodeLog(data []byte) (*DecodedLog, error) {
+	res, err := bch.UnPackEGTXLog(data)
+	if err != nil {
+		return nil, err
+	}
+	decoded := &DecodedLog{
+		Confirmations:    -1, // all ones
+		Outputs:          decodeAddressAndValues(res[1].([]*big.Int)),
+		Inputs:           decodeAddressAndValues(res[2].([]*big.Int)),
+		OutputTokenInfos: decodeTokenInfos(*abi.ConvertType(res[3], new([]bch.TokenInfo)).(*[]bch.TokenInfo)),
+		InputTokenInfos:  decodeTokenInfos(*abi.ConvertType(res[4], new([]bch.TokenInfo)).(*[]bch.TokenInfo)),
+	}
+	if confirmations := res[0].(*big.Int); confirmations.IsInt64() {
+		decoded.Confirmations = confirmations.Int64()
+	}
+	for _, d := range res[5].([][]byte) {
+		decoded.OtherData = append(decoded.OtherData, d)
+	}
+	return decoded, nil
+}
+
+// decodeAddressAndValues splits the words packed by bch.PackAddressAndValue
+func decodeAddressAndValues(words []*big.Int) []AddressAndValue {
+	res := make([]AddressAndValue, len(words))
+	for i, word := range words {
+		w := toWord(word)
+		res[i] = AddressAndValue{
+			Address: common.BytesToAddress(w[:20]),
+			Value:   (*hexutil.Big)(new(big.Int).SetBytes(w[20:])),
+		}
+	}
+	return res
+}
+
+func decodeTokenInfos(tokenInfos []bch.TokenInfo) []DecodedTokenInfo {
+	res := make([]DecodedTokenInfo, len(tokenInfos))
+	for i, info := range tokenInfos {
+		addressAndAmount := toWord(info.AddressAndTokenAmount)
+		res[i] = DecodedTokenInfo{
+			Address:       common.BytesToAddress(addressAndAmount[:20]),
+			TokenAmount:   (*hexutil.Big)(new(big.Int).SetBytes(addressAndAmount[20:])),
+			TokenCategory: toWord(info.TokenCategory),
+		}
+		// the length, capability and leading 8 bytes of the commitment, followed by the trailing 32 bytes
+		head, tail := toWord(info.NftCommitmentLengthAndHead), toWord(info.NftCommitmentTail)
+		if int(head[1]) < len(nftCapabilities) {
+			res[i].NftCapability = nftCapabilities[head[1]]
+		}
+		if length := int(head[0]); length > 0 && length <= 40 {
+			res[i].NftCommitment = append(common.CopyBytes(head[24:]), tail[:]...)[:length]
+		}
+	}
+	return res
+}
+
+func toWord(x *big.Int) (w common.Hash) {
+	x.FillBytes(w[:])
+	return
+}
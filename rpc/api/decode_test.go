@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	gethcmn "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+func TestDecodeLog(t *testing.T) {
+	payer, payee := gethcmn.Address{0x02}, gethcmn.Address{0x03}
+	category, _ := hex.DecodeString("0f3dd8905e42c5911245da88ca3600bb4f39d9cbcad7672512e59d059b2428b6")
+	var head, tail [32]byte
+	head[0], head[1], head[24], head[31] = 9, 2, 0xaa, 0xbb
+	tail[0] = 0xcc
+	var addressAndAmount [32]byte
+	copy(addressAndAmount[:20], payee[:])
+	addressAndAmount[31] = 10
+	tokenInfo := bch.TokenInfo{
+		AddressAndTokenAmount:      new(big.Int).SetBytes(addressAndAmount[:]),
+		TokenCategory:              new(big.Int).SetBytes(category),
+		NftCommitmentLengthAndHead: new(big.Int).SetBytes(head[:]),
+		NftCommitmentTail:          new(big.Int).SetBytes(tail[:]),
+	}
+	data := bch.BuildLogData(uint256.NewInt(3),
+		[][32]byte{bch.PackAddressAndValue(payee[:], 1e8)},
+		[][32]byte{bch.PackAddressAndValue(payer[:], 2e8)},
+		[]bch.TokenInfo{tokenInfo}, nil, [][]byte{{7}})
+
+	decoded, err := decodeLog(data)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, decoded.Confirmations)
+	require.Len(t, decoded.Outputs, 1)
+	require.Equal(t, payee, decoded.Outputs[0].Address)
+	require.Equal(t, big.NewInt(1e18), decoded.Outputs[0].Value.ToInt())
+	require.Equal(t, payer, decoded.Inputs[0].Address)
+	require.Equal(t, big.NewInt(2e18), decoded.Inputs[0].Value.ToInt())
+	require.Len(t, decoded.OutputTokenInfos, 1)
+	require.Empty(t, decoded.InputTokenInfos)
+	info := decoded.OutputTokenInfos[0]
+	require.Equal(t, payee, info.Address)
+	require.EqualValues(t, 10, info.TokenAmount.ToInt().Int64())
+	require.Equal(t, gethcmn.BytesToHash(category), info.TokenCategory)
+	require.Equal(t, "mutable", info.NftCapability)
+	require.EqualValues(t, []byte{0xaa, 0, 0, 0, 0, 0, 0, 0xbb, 0xcc}, info.NftCommitment)
+	require.EqualValues(t, [][]byte{{7}}, [][]byte{decoded.OtherData[0]})
+
+	// the confirmations are all ones if the tx is not found any more
+	decoded, err = decodeLog(newConfirmedLog(&gethtypes.Log{Data: data}, -1).Log.Data)
+	require.NoError(t, err)
+	require.EqualValues(t, -1, decoded.Confirmations)
+
+	_, err = decodeLog([]byte{1, 2, 3})
+	require.Error(t, err)
+}
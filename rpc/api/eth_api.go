@@ -22,7 +22,13 @@ type PublicEthAPI interface {
 	ChainId() hexutil.Uint64
 	BlockNumber() (hexutil.Uint64, error)
 	GetBlockByNumber(blockNum gethrpc.BlockNumber, fullTx bool) (map[string]interface{}, error)
+	GetBlockByHash(hash gethcmn.Hash, fullTx bool) (map[string]interface{}, error)
+	GetBlockTransactionCountByNumber(blockNum gethrpc.BlockNumber) (*hexutil.Uint, error)
+	GetBlockTransactionCountByHash(hash gethcmn.Hash) (*hexutil.Uint, error)
 	GetTransactionByHash(hash gethcmn.Hash) (*Transaction, error)
+	GetTransactionByBlockNumberAndIndex(blockNum gethrpc.BlockNumber, idx hexutil.Uint) (*Transaction, error)
+	GetTransactionByBlockHashAndIndex(hash gethcmn.Hash, idx hexutil.Uint) (*Transaction, error)
+	GetTransactionReceipt(hash gethcmn.Hash) (map[string]interface{}, error)
 }
 
 type ethAPI struct {
@@ -38,7 +44,7 @@ func newEthAPI(backend api.BackendService, logger log.Logger) *ethAPI {
 }
 
 func (api *ethAPI) ChainId() hexutil.Uint64 {
-	return hexutil.Uint64(api.backend.ChainId().Uint64())
+	return hexutil.Uint64(api.backend.ChainId())
 }
 
 // https://eth.wiki/json-rpc/API#eth_blockNumber
@@ -46,25 +52,72 @@ func (api *ethAPI) BlockNumber() (hexutil.Uint64, error) {
 	return hexutil.Uint64(api.backend.LatestHeight()), nil
 }
 
+// resolveBlockNumber maps the block tags to heights. There is no genesis block, so the earliest one is 1, and the
+// virtual blocks are never reverted, so the latest one is also safe and finalized.
+func (api *ethAPI) resolveBlockNumber(blockNum gethrpc.BlockNumber) int64 {
+	switch blockNum {
+	case gethrpc.EarliestBlockNumber:
+		return 1
+	case gethrpc.LatestBlockNumber, gethrpc.PendingBlockNumber, gethrpc.SafeBlockNumber, gethrpc.FinalizedBlockNumber:
+		return api.backend.LatestHeight()
+	}
+	return blockNum.Int64()
+}
+
+// getBlock returns nil if the block is not found
+func (api *ethAPI) getBlock(blockNum gethrpc.BlockNumber) (*mevmtypes.Block, error) {
+	block, err := api.backend.BlockByNumber(api.resolveBlockNumber(blockNum))
+	if err == mevmtypes.ErrBlockNotFound {
+		return nil, nil
+	}
+	return block, err
+}
+
+// getBlockByHash returns nil if the block is not found
+func (api *ethAPI) getBlockByHash(hash gethcmn.Hash) (*mevmtypes.Block, error) {
+	block, err := api.backend.BlockByHash(hash)
+	if err == mevmtypes.ErrBlockNotFound {
+		return nil, nil
+	}
+	return block, err
+}
+
 // https://eth.wiki/json-rpc/API#eth_getBlockByNumber
 func (api *ethAPI) GetBlockByNumber(blockNum gethrpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
-	block, err := api.backend.BlockByNumber(int64(blockNum))
-	if err != nil {
-		if err == mevmtypes.ErrBlockNotFound {
-			return nil, nil
-		}
+	block, err := api.getBlock(blockNum)
+	if block == nil || err != nil {
 		return nil, err
 	}
+	return api.blockToRpcResp(block, fullTx)
+}
 
-	//var txs []*types.Transaction
-	//var sigs [][65]byte
-	//if fullTx {
-	//	txs, sigs, err = api.backend.GetTxListByHeight(uint32(block.Number))
-	//	if err != nil {
-	//		return nil, err
-	//	}
-	//}
-	return blockToRpcResp(block), nil
+// https://eth.wiki/json-rpc/API#eth_getBlockByHash
+func (api *ethAPI) GetBlockByHash(hash gethcmn.Hash, fullTx bool) (map[string]interface{}, error) {
+	block, err := api.getBlockByHash(hash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	return api.blockToRpcResp(block, fullTx)
+}
+
+// https://eth.wiki/json-rpc/API#eth_getBlockTransactionCountByNumber
+func (api *ethAPI) GetBlockTransactionCountByNumber(blockNum gethrpc.BlockNumber) (*hexutil.Uint, error) {
+	block, err := api.getBlock(blockNum)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	n := hexutil.Uint(len(block.Transactions))
+	return &n, nil
+}
+
+// https://eth.wiki/json-rpc/API#eth_getBlockTransactionCountByHash
+func (api *ethAPI) GetBlockTransactionCountByHash(hash gethcmn.Hash) (*hexutil.Uint, error) {
+	block, err := api.getBlockByHash(hash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	n := hexutil.Uint(len(block.Transactions))
+	return &n, nil
 }
 
 // https://eth.wiki/json-rpc/API#eth_getTransactionByHash
@@ -76,6 +129,67 @@ func (api *ethAPI) GetTransactionByHash(hash gethcmn.Hash) (*Transaction, error)
 	return txToRpcResp(tx), nil
 }
 
+// https://eth.wiki/json-rpc/API#eth_gettransactionreceipt
+func (api *ethAPI) GetTransactionReceipt(hash gethcmn.Hash) (map[string]interface{}, error) {
+	tx, _, err := api.backend.GetTx(hash)
+	if err != nil {
+		return nil, nil
+	}
+	logs := make([]*gethtypes.Log, len(tx.Logs))
+	if len(tx.Logs) > 0 {
+		confirmations := api.backend.Confirmations(hash)
+		for i, l := range mevmtypes.ToGethLogs(tx.Logs) {
+			logs[i] = newConfirmedLog(l, confirmations).Log
+		}
+	}
+	return txToReceiptRpcResp(tx, logs), nil
+}
+
+// https://eth.wiki/json-rpc/API#eth_getTransactionByBlockNumberAndIndex
+func (api *ethAPI) GetTransactionByBlockNumberAndIndex(blockNum gethrpc.BlockNumber, idx hexutil.Uint) (*Transaction, error) {
+	block, err := api.getBlock(blockNum)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	return api.getTxInBlock(block, idx), nil
+}
+
+// https://eth.wiki/json-rpc/API#eth_getTransactionByBlockHashAndIndex
+func (api *ethAPI) GetTransactionByBlockHashAndIndex(hash gethcmn.Hash, idx hexutil.Uint) (*Transaction, error) {
+	block, err := api.getBlockByHash(hash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	return api.getTxInBlock(block, idx), nil
+}
+
+func (api *ethAPI) getTxInBlock(block *mevmtypes.Block, idx hexutil.Uint) *Transaction {
+	if int(idx) >= len(block.Transactions) {
+		return nil
+	}
+	tx, _, err := api.backend.GetTx(block.Transactions[idx])
+	if err != nil {
+		return nil
+	}
+	return txToRpcResp(tx)
+}
+
+func (api *ethAPI) blockToRpcResp(block *mevmtypes.Block, fullTx bool) (map[string]interface{}, error) {
+	result := blockToRpcResp(block)
+	if fullTx {
+		txs, _, err := api.backend.GetTxListByHeight(uint32(block.Number))
+		if err != nil {
+			return nil, err
+		}
+		rpcTxs := make([]*Transaction, len(txs))
+		for i, tx := range txs {
+			rpcTxs[i] = txToRpcResp(tx)
+		}
+		result["transactions"] = rpcTxs
+	}
+	return result, nil
+}
+
 func blockToRpcResp(block *mevmtypes.Block) map[string]interface{} {
 	result := map[string]interface{}{
 		"number":           hexutil.Uint64(block.Number),
@@ -83,7 +197,7 @@ func blockToRpcResp(block *mevmtypes.Block) map[string]interface{} {
 		"parentHash":       hexutil.Bytes(block.ParentHash[:]),
 		"nonce":            hexutil.Bytes(make([]byte, 8)), // PoW specific
 		"sha3Uncles":       gethcmn.Hash{},                 // No uncles in Tendermint
-		"logsBloom":        gethtypes.Bloom{},
+		"logsBloom":        gethtypes.Bloom(block.LogsBloom),
 		"transactionsRoot": hexutil.Bytes(block.TransactionsRoot[:]),
 		"stateRoot":        hexutil.Bytes(block.StateRoot[:]),
 		"miner":            hexutil.Bytes(block.Miner[:]),
@@ -100,14 +214,6 @@ func blockToRpcResp(block *mevmtypes.Block) map[string]interface{} {
 		"receiptsRoot":     gethcmn.Hash{},
 	}
 
-	//if len(txs) > 0 {
-	//	rpcTxs := make([]*Transaction, len(txs))
-	//	for i, tx := range txs {
-	//		rpcTxs[i] = txToRpcResp(tx, sigs[i])
-	//	}
-	//	result["transactions"] = rpcTxs
-	//}
-
 	return result
 }
 
@@ -135,3 +241,32 @@ func txToRpcResp(tx *mevmtypes.Transaction) *Transaction {
 	}
 	return resp
 }
+
+// txToReceiptRpcResp builds the receipt of an EGTX, which never fails as it is not executed
+func txToReceiptRpcResp(tx *mevmtypes.Transaction, logs []*gethtypes.Log) map[string]interface{} {
+	bloom := gethtypes.Bloom(tx.LogsBloom)
+	if bloom == (gethtypes.Bloom{}) {
+		// the txs stored by older versions have no bloom
+		bloom = gethtypes.BytesToBloom(gethtypes.LogsBloom(logs))
+	}
+	receipt := map[string]interface{}{
+		"blockHash":         gethcmn.Hash(tx.BlockHash),
+		"blockNumber":       hexutil.Uint64(tx.BlockNumber),
+		"transactionHash":   gethcmn.Hash(tx.Hash),
+		"transactionIndex":  hexutil.Uint64(tx.TransactionIndex),
+		"from":              gethcmn.Address(tx.From),
+		"to":                nil,
+		"gasUsed":           hexutil.Uint64(tx.GasUsed),
+		"cumulativeGasUsed": hexutil.Uint64(tx.CumulativeGasUsed),
+		"effectiveGasPrice": (*hexutil.Big)(big.NewInt(0).SetBytes(tx.GasPrice[:])),
+		"contractAddress":   nil,
+		"logs":              logs,
+		"logsBloom":         bloom,
+		"type":              hexutil.Uint(gethtypes.LegacyTxType),
+		"status":            hexutil.Uint(gethtypes.ReceiptStatusSuccessful),
+	}
+	if tx.To != [20]byte{} {
+		receipt["to"] = gethcmn.Address(tx.To)
+	}
+	return receipt
+}
@@ -1,14 +1,19 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	gethcmn "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	mevmtypes "github.com/smartbch/moeingevm/types"
 
 	"github.com/elfinguard/chainlogs/testchain"
 	"github.com/tendermint/tendermint/libs/log"
@@ -83,6 +88,86 @@ func TestGetBlockByNum(t *testing.T) {
 }`, toJSON(result))
 }
 
+func TestGetBlockByNum_tags(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+	_api := newEthAPI(vc.NewBackend(), log.NewNopLogger())
+
+	_, hash1 := vc.GenNewBlock()
+	_, hash2 := vc.GenNewBlock()
+	for _, tag := range []gethrpc.BlockNumber{gethrpc.LatestBlockNumber, gethrpc.PendingBlockNumber,
+		gethrpc.SafeBlockNumber, gethrpc.FinalizedBlockNumber} {
+		result, err := _api.GetBlockByNumber(tag, false)
+		require.NoError(t, err)
+		require.Equal(t, hexutil.Bytes(hash2[:]), result["hash"])
+	}
+	result, err := _api.GetBlockByNumber(gethrpc.EarliestBlockNumber, false)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Bytes(hash1[:]), result["hash"])
+}
+
+func TestGetBlockByHash(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+	_api := newEthAPI(vc.NewBackend(), log.NewNopLogger())
+
+	vc.AddTx(gethcmn.Hash{0xC1})
+	vc.AddTx(gethcmn.Hash{0xC2})
+	bNum, bHash := vc.GenNewBlock()
+
+	result, err := _api.GetBlockByHash(bHash, true)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(bNum), result["number"])
+	txs := result["transactions"].([]*Transaction)
+	require.Len(t, txs, 2)
+	require.Equal(t, gethcmn.Hash{0xC1}, txs[0].Hash)
+	require.Equal(t, &bHash, txs[1].BlockHash)
+
+	result, err = _api.GetBlockByHash(gethcmn.Hash{0xFF}, true)
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestGetBlockTxCount(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+	_api := newEthAPI(vc.NewBackend(), log.NewNopLogger())
+
+	vc.AddTx(gethcmn.Hash{0xC1})
+	vc.AddTx(gethcmn.Hash{0xC2})
+	bNum, bHash := vc.GenNewBlock()
+
+	n, err := _api.GetBlockTransactionCountByNumber(gethrpc.BlockNumber(bNum))
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint(2), *n)
+	n, err = _api.GetBlockTransactionCountByHash(bHash)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint(2), *n)
+	n, err = _api.GetBlockTransactionCountByNumber(100)
+	require.NoError(t, err)
+	require.Nil(t, n)
+}
+
+func TestGetTxByBlockAndIndex(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+	_api := newEthAPI(vc.NewBackend(), log.NewNopLogger())
+
+	vc.AddTx(gethcmn.Hash{0xC1})
+	vc.AddTx(gethcmn.Hash{0xC2})
+	bNum, bHash := vc.GenNewBlock()
+
+	tx, err := _api.GetTransactionByBlockNumberAndIndex(gethrpc.BlockNumber(bNum), 1)
+	require.NoError(t, err)
+	require.Equal(t, gethcmn.Hash{0xC2}, tx.Hash)
+	tx, err = _api.GetTransactionByBlockHashAndIndex(bHash, 0)
+	require.NoError(t, err)
+	require.Equal(t, gethcmn.Hash{0xC1}, tx.Hash)
+	tx, err = _api.GetTransactionByBlockNumberAndIndex(gethrpc.BlockNumber(bNum), 2)
+	require.NoError(t, err)
+	require.Nil(t, tx)
+}
+
 func TestGetTxByHash(t *testing.T) {
 	vc := testchain.CreateTestChain()
 	defer vc.Destroy()
@@ -105,3 +190,53 @@ func toJSON(v interface{}) string {
 	}
 	return string(bs)
 }
+
+func TestGetTxReceipt(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+	_api := newEthAPI(vc.NewBackend(), log.NewNopLogger())
+
+	txHash := gethcmn.Hash{0xC1}
+	vc.AddTx(txHash, mevmtypes.Log{
+		Address: gethcmn.Address{0xA1},
+		Topics:  [][32]byte{{0xB1}},
+		Data:    make([]byte, 64),
+	})
+	bNum, bHash := vc.GenNewBlock()
+
+	receipt, err := _api.GetTransactionReceipt(txHash)
+	require.NoError(t, err)
+	require.Equal(t, bHash, receipt["blockHash"])
+	require.Equal(t, hexutil.Uint64(bNum), receipt["blockNumber"])
+	require.Equal(t, txHash, receipt["transactionHash"])
+	require.Equal(t, hexutil.Uint(1), receipt["status"])
+	logs := receipt["logs"].([]*gethtypes.Log)
+	require.Len(t, logs, 1)
+	require.Equal(t, gethcmn.Address{0xA1}, logs[0].Address)
+	bloom := receipt["logsBloom"].(gethtypes.Bloom)
+	require.True(t, bloom.Test(gethcmn.Address{0xA1}.Bytes()))
+	require.True(t, bloom.Test(gethcmn.Hash{0xB1}.Bytes()))
+	require.False(t, bloom.Test(gethcmn.Address{0xA2}.Bytes()))
+
+	block, err := _api.GetBlockByNumber(gethrpc.BlockNumber(bNum), false)
+	require.NoError(t, err)
+	require.Equal(t, bloom, block["logsBloom"])
+
+	receipt, err = _api.GetTransactionReceipt(gethcmn.Hash{0xC2})
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+}
+
+func TestChainId(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+	backend := vc.NewBackend()
+	_api := newEthAPI(backend, log.NewNopLogger())
+
+	chainId := _api.ChainId()
+	require.NotZero(t, chainId)
+	require.Equal(t, hexutil.Uint64(vc.EvmChainId), chainId)
+	require.Equal(t, strconv.FormatUint(uint64(chainId), 10), newNetAPI(backend).Version())
+	identity := (&chainLogsAPI{backend: backend}).ChainIdentity()
+	require.Equal(t, "virtual Bitcoin Cash", string(bytes.TrimRight(identity[:], "\x00")))
+}
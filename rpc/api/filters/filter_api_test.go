@@ -2,10 +2,13 @@ package filters
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum"
 	gethcmn "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	gethfilters "github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/tendermint/tendermint/libs/log"
 
@@ -90,3 +93,71 @@ func TestGetLogs_blockHashFilter(t *testing.T) {
 	_, err = _api.GetLogs(gethfilters.FilterCriteria{BlockHash: &b3Hash})
 	require.Error(t, err)
 }
+
+func TestSubscribeLogs_removed(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+
+	es := NewEventSystem(vc.NewBackend(), false)
+	logsCh := make(chan []*gethtypes.Log)
+	sub, err := es.SubscribeLogs(ethereum.FilterQuery{}, logsCh)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	addr1 := gethcmn.Address{0xA1}
+	txId1 := gethcmn.Hash{0xC1}
+	vc.AddTx(txId1, mevmtypes.Log{Address: addr1})
+	vc.GenNewBlock()
+	logs := waitLogs(t, logsCh)
+	require.Len(t, logs, 1)
+	require.Equal(t, addr1, logs[0].Address)
+	require.False(t, logs[0].Removed)
+
+	vc.RemoveTx(txId1)
+	vc.GenNewBlock()
+	logs = waitLogs(t, logsCh)
+	require.Len(t, logs, 1)
+	require.Equal(t, addr1, logs[0].Address)
+	require.True(t, logs[0].Removed)
+}
+
+func TestSubscribeLogs_lightMode(t *testing.T) {
+	vc := testchain.CreateTestChain()
+	defer vc.Destroy()
+
+	es := NewEventSystem(vc.NewBackend(), true)
+	addr1, addr2 := gethcmn.Address{0xA1}, gethcmn.Address{0xA2}
+	logsCh := make(chan []*gethtypes.Log)
+	sub, err := es.SubscribeLogs(ethereum.FilterQuery{Addresses: []gethcmn.Address{addr1}}, logsCh)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// the bloom of this block does not match
+	vc.AddTx(gethcmn.Hash{0xC1}, mevmtypes.Log{Address: addr2})
+	vc.GenNewBlock()
+	vc.AddTx(gethcmn.Hash{0xC2}, mevmtypes.Log{Address: addr2}, mevmtypes.Log{Address: addr1, Data: make([]byte, 32)})
+	vc.GenNewBlock()
+	logs := waitLogs(t, logsCh)
+	require.Len(t, logs, 1)
+	require.Equal(t, addr1, logs[0].Address)
+	require.Equal(t, gethcmn.Hash{0xC2}, logs[0].TxHash)
+
+	// the log indexes run through the block
+	vc.AddTx(gethcmn.Hash{0xC3}, mevmtypes.Log{Address: addr1, Data: make([]byte, 32)})
+	vc.AddTx(gethcmn.Hash{0xC4}, mevmtypes.Log{Address: addr1, Data: make([]byte, 32)})
+	vc.GenNewBlock()
+	logs = waitLogs(t, logsCh)
+	require.Len(t, logs, 2)
+	require.EqualValues(t, 0, logs[0].Index)
+	require.EqualValues(t, 1, logs[1].Index)
+}
+
+func waitLogs(t *testing.T, logsCh chan []*gethtypes.Log) []*gethtypes.Log {
+	select {
+	case logs := <-logsCh:
+		return logs
+	case <-time.After(time.Second):
+		require.FailNow(t, "logs not received")
+	}
+	return nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 
+	modbtypes "github.com/smartbch/moeingdb/types"
 	motypes "github.com/smartbch/moeingevm/types"
 )
 
@@ -85,7 +86,7 @@ type subscription struct {
 type EventSystem struct {
 	backend   Backend
 	lightMode bool
-	//lastHead  *motypes.Header
+	lastHead  *motypes.Header // the last head filtered in light mode, only accessed by the event loop
 
 	// Subscriptions
 	txsSub    event.Subscription // Subscription for new transaction event
@@ -309,7 +310,8 @@ func (es *EventSystem) SubscribeNewHeads(headers chan *motypes.Header) *Subscrip
 type filterIndex map[Type]map[rpc.ID]*subscription
 
 func (es *EventSystem) handleLogs(filters filterIndex, ev []*types.Log) {
-	if len(ev) == 0 {
+	// in light mode, the new logs are found by the bloom of the new heads
+	if len(ev) == 0 || es.lightMode {
 		return
 	}
 	for _, f := range filters[LogsSubscription] {
@@ -355,90 +357,52 @@ func (es *EventSystem) handleChainEvent(filters filterIndex, ev motypes.ChainEve
 	for _, f := range filters[BlocksSubscription] {
 		f.headers <- ev.BlockHeader
 	}
-	//if es.lightMode && len(filters[LogsSubscription]) > 0 {
-	//	es.lightFilterNewHead(ev.BlockHeader, func(header *motypes.Header, remove bool) {
-	//		for _, f := range filters[LogsSubscription] {
-	//			if matchedLogs := es.lightFilterLogs(header, f.logsCrit.Addresses, f.logsCrit.Topics, remove); len(matchedLogs) > 0 {
-	//				f.logs <- matchedLogs
-	//			}
-	//		}
-	//	})
-	//}
+	if es.lightMode && len(filters[LogsSubscription]) > 0 {
+		es.lightFilterNewHead(ev.BlockHeader, func(header *motypes.Header, remove bool) {
+			for _, f := range filters[LogsSubscription] {
+				if matchedLogs := es.lightFilterLogs(header, ev.Block, f.logsCrit.Addresses, f.logsCrit.Topics, remove); len(matchedLogs) > 0 {
+					f.logs <- matchedLogs
+				}
+			}
+		})
+	}
 }
 
-/*
+// lightFilterNewHead calls callBack with the new head. Unlike geth, the virtual blocks are never reverted, so there
+// is no common ancestor to look for, only the heads not newer than the last one are skipped.
 func (es *EventSystem) lightFilterNewHead(newHeader *motypes.Header, callBack func(*motypes.Header, bool)) {
 	oldh := es.lastHead
-	es.lastHead = newHeader
-	if oldh == nil {
+	if oldh != nil && newHeader.Number <= oldh.Number {
 		return
 	}
-	newh := newHeader
-	// find common ancestor, create list of rolled back and new block hashes
-	var oldHeaders, newHeaders []*motypes.Header
-	for oldh.Hash() != newh.Hash() {
-		if oldh.Number >= newh.Number {
-			oldHeaders = append(oldHeaders, oldh)
-			oldh = rawdb.ReadHeader(es.backend.ChainDb(), oldh.ParentHash, oldh.Number-1)
+	es.lastHead = newHeader
+	callBack(newHeader, false)
+}
+
+// filter logs of a single header in light client mode, the txs are decoded from the published block instead of
+// being read from the store again. The log indexes run through the block.
+func (es *EventSystem) lightFilterLogs(header *motypes.Header, block *modbtypes.Block, addresses []common.Address, topics [][]common.Hash, remove bool) []*types.Log {
+	if block == nil || !bloomFilter(header.Bloom, addresses, topics) {
+		return nil
+	}
+	var unfiltered []*types.Log
+	for _, mdbTx := range block.TxList {
+		tx := &motypes.Transaction{}
+		if _, err := tx.UnmarshalMsg(mdbTx.Content); err != nil {
+			continue
 		}
-		if oldh.Number < newh.Number {
-			newHeaders = append(newHeaders, newh)
-			newh = rawdb.ReadHeader(es.backend.ChainDb(), newh.ParentHash, newh.Number-1)
-			if newh == nil {
-				// happens when CHT syncing, nothing to do
-				newh = oldh
-			}
+		for _, log := range motypes.ToGethLogs(tx.Logs) {
+			log.BlockNumber = uint64(header.Number)
+			log.BlockHash = header.BlockHash
+			log.TxHash = tx.Hash
+			log.TxIndex = uint(tx.TransactionIndex)
+			log.Index = uint(len(unfiltered))
+			log.Removed = remove
+			unfiltered = append(unfiltered, log)
 		}
 	}
-	// roll back old blocks
-	for _, h := range oldHeaders {
-		callBack(h, true)
-	}
-	// check new blocks (array is in reverse order)
-	for i := len(newHeaders) - 1; i >= 0; i-- {
-		callBack(newHeaders[i], false)
-	}
+	return filterLogs(unfiltered, nil, nil, addresses, topics)
 }
-*/
-// filter logs of a single header in light client mode
-//func (es *EventSystem) lightFilterLogs(header *motypes.Header, addresses []common.Address, topics [][]common.Hash, remove bool) []*types.Log {
-//	if bloomFilter(header.Bloom, addresses, topics) {
-//		// Get the logs of the block
-//		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-//		defer cancel()
-//		logsList, err := es.backend.GetLogs(ctx, header.Hash())
-//		if err != nil {
-//			return nil
-//		}
-//		var unfiltered []*types.Log
-//		for _, logs := range logsList {
-//			for _, log := range logs {
-//				logcopy := *log
-//				logcopy.Removed = remove
-//				unfiltered = append(unfiltered, &logcopy)
-//			}
-//		}
-//		logs := filterLogs(unfiltered, nil, nil, addresses, topics)
-//		if len(logs) > 0 && logs[0].TxHash == (common.Hash{}) {
-//			// We have matching but non-derived logs
-//			receipts, err := es.backend.GetReceipts(ctx, header.Hash())
-//			if err != nil {
-//				return nil
-//			}
-//			unfiltered = unfiltered[:0]
-//			for _, receipt := range receipts {
-//				for _, log := range receipt.Logs {
-//					logcopy := *log
-//					logcopy.Removed = remove
-//					unfiltered = append(unfiltered, &logcopy)
-//				}
-//			}
-//			logs = filterLogs(unfiltered, nil, nil, addresses, topics)
-//		}
-//		return logs
-//	}
-//	return nil
-//}
 
 // eventLoop (un)installs filters and processes mux events.
 func (es *EventSystem) eventLoop() {
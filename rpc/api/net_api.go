@@ -0,0 +1,21 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/elfinguard/chainlogs/api"
+)
+
+// netAPI serves net_version, some tools ask it instead of eth_chainId
+type netAPI struct {
+	backend api.BackendService
+}
+
+func newNetAPI(backend api.BackendService) *netAPI {
+	return &netAPI{backend: backend}
+}
+
+// Version returns the EVM chain id in decimal, the same as eth_chainId
+func (api *netAPI) Version() string {
+	return strconv.FormatUint(api.backend.ChainId(), 10)
+}
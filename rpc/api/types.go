@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // Copied the Transaction, SendTxArgs and CallArgs types since they are registered under an
@@ -51,3 +52,49 @@ type CallArgs struct {
 	Value    *hexutil.Big    `json:"value"`
 	Data     *hexutil.Bytes  `json:"data"`
 }
+
+// SignedLog is a log attested by the adaptor's operator, the signature covers the digest of signer.LogDigest
+type SignedLog struct {
+	Log           *gethtypes.Log `json:"log"`
+	Confirmations hexutil.Uint64 `json:"confirmations"`
+	Signer        common.Address `json:"signer"`
+	Signature     hexutil.Bytes  `json:"signature"`
+}
+
+// ConfirmedCriteria selects the logs of chainlogs_subscribe("confirmed"), like the filter of eth_subscribe("logs")
+type ConfirmedCriteria struct {
+	Addresses        []common.Address `json:"addresses"`
+	Topics           [][]common.Hash  `json:"topics"`
+	MinConfirmations int32            `json:"minConfirmations"`
+}
+
+// ConfirmedLog is notified when a log's tx reaches the confirmations threshold, or with -1 confirmations
+// when the tx disappears from the main chain after it is tracked
+type ConfirmedLog struct {
+	Log           *gethtypes.Log `json:"log"`
+	Confirmations int32          `json:"confirmations"`
+}
+
+// DecodedLog is the EGTXLogData of an EGTX log, the values of outputs and inputs are in wei (1 satoshi = 1e10 wei)
+type DecodedLog struct {
+	Confirmations    int64              `json:"confirmations"` // -1 if the tx is not found any more
+	Outputs          []AddressAndValue  `json:"outputs"`
+	Inputs           []AddressAndValue  `json:"inputs"`
+	OutputTokenInfos []DecodedTokenInfo `json:"outputTokenInfos"`
+	InputTokenInfos  []DecodedTokenInfo `json:"inputTokenInfos"`
+	OtherData        []hexutil.Bytes    `json:"otherData"`
+}
+
+type AddressAndValue struct {
+	Address common.Address `json:"address"`
+	Value   *hexutil.Big   `json:"value"`
+}
+
+// DecodedTokenInfo is a TokenInfo of EGTXLogData, the NFT fields are empty if the output has no NFT
+type DecodedTokenInfo struct {
+	Address       common.Address `json:"address"`
+	TokenAmount   *hexutil.Big   `json:"tokenAmount"`
+	TokenCategory common.Hash    `json:"tokenCategory"`
+	NftCapability string         `json:"nftCapability,omitempty"` // "none", "mutable" or "minting"
+	NftCommitment hexutil.Bytes  `json:"nftCommitment,omitempty"`
+}
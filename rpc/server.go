@@ -40,27 +40,43 @@ type Server struct {
 	wsAPIs       []string
 	serverConfig *tmrpcserver.Config
 
-	logger  tmlog.Logger
-	backend api.BackendService
+	logger tmlog.Logger
+	routes []*route
 
-	httpServer   *gethrpc.Server
 	httpListener net.Listener
-	wsServer     *gethrpc.Server
 	wsListener   net.Listener
 
 	httpsListener net.Listener
 	wssListener   net.Listener
 }
 
-func NewAndStartServer(vc *chains.VirtualChain, rootDir string, rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain string, logger tmlog.Logger) (tmservice.Service, error) {
+// Route serves a virtual chain's APIs under a URL path, such as "/bch".
+// The path "/" matches all the requests which are not matched by other routes.
+type Route struct {
+	Path string
+	VC   *chains.VirtualChain
+}
+
+// route holds the backend and RPC servers of a Route, so every virtual chain has its own filter EventSystem
+type route struct {
+	path       string
+	backend    api.BackendService
+	httpServer *gethrpc.Server
+	wsServer   *gethrpc.Server
+}
+
+func NewAndStartServer(routes []Route, rootDir string, rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain string, logger tmlog.Logger) (tmservice.Service, error) {
 	serverCfg := tmrpcserver.DefaultConfig()
-	rpcBackend := api.NewBackend(vc)
+	backends := make(map[string]api.BackendService, len(routes))
+	for _, r := range routes {
+		backends[r.Path] = api.NewBackend(r.VC)
+	}
 	certDir := filepath.Join(rootDir, "nodeCfg/cert.pem")
 	keyDir := filepath.Join(rootDir, "nodeCfg/key.pem")
-	httpAPI := "eth"
-	wsAPI := "eth"
+	httpAPI := "eth,net,chainlogs"
+	wsAPI := "eth,net,chainlogs"
 	rpcServer := NewServer(rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, certDir, keyDir,
-		serverCfg, rpcBackend, logger, nil, httpAPI, wsAPI)
+		serverCfg, backends, logger, nil, httpAPI, wsAPI)
 	if err := rpcServer.Start(); err != nil {
 		return rpcServer, err
 	}
@@ -68,10 +84,15 @@ func NewAndStartServer(vc *chains.VirtualChain, rootDir string, rpcAddr, wsAddr,
 }
 
 func NewServer(rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, certFile, keyFile string,
-	serverCfg *tmrpcserver.Config, backend api.BackendService,
+	serverCfg *tmrpcserver.Config, backends map[string]api.BackendService,
 	logger tmlog.Logger, unlockedKeys []string,
 	httpAPI string, wsAPI string) tmservice.Service {
 
+	var routes []*route
+	for path, backend := range backends {
+		routes = append(routes, &route{path: path, backend: backend})
+	}
+
 	impl := &Server{
 		rpcAddr:      rpcAddr,
 		wsAddr:       wsAddr,
@@ -79,7 +100,7 @@ func NewServer(rpcAddr, wsAddr, rpcAddrSecure, wsAddrSecure, corsDomain, certFil
 		certFile:     certFile,
 		keyFile:      keyFile,
 		serverConfig: serverCfg,
-		backend:      backend,
+		routes:       routes,
 		logger:       logger,
 		rpcHttpsAddr: rpcAddrSecure, //"tcp://:9545",
 		wssAddr:      wsAddrSecure,  //"tcp://:9546",
@@ -100,21 +121,41 @@ func splitAndTrim(input string) (ret []string) {
 }
 
 func (server *Server) OnStart() error {
-	apis := rpcapi.GetAPIs(server.backend, server.logger)
-	if err := server.startHTTPAndHTTPS(apis); err != nil {
+	for _, r := range server.routes {
+		apis := rpcapi.GetAPIs(r.backend, server.logger.With("path", r.path))
+		r.httpServer = gethrpc.NewServer()
+		if err := registerApis(r.httpServer, server.httpAPIs, apis); err != nil {
+			return err
+		}
+		r.wsServer = gethrpc.NewServer()
+		if err := registerApis(r.wsServer, server.wsAPIs, apis); err != nil {
+			return err
+		}
+	}
+	if err := server.startHTTPAndHTTPS(); err != nil {
 		return err
 	}
-	return server.startWSAndWSS(apis)
+	return server.startWSAndWSS()
 }
 
-func (server *Server) startHTTPAndHTTPS(apis []gethrpc.API) (err error) {
-	server.httpServer = gethrpc.NewServer()
-	if err = registerApis(server.httpServer, server.httpAPIs, apis); err != nil {
-		return err
+// newMux dispatches the requests to the routes by URL path
+func (server *Server) newMux(getHandler func(r *route) http.Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, r := range server.routes {
+		h := getHandler(r)
+		mux.Handle(r.path, h)
+		if !strings.HasSuffix(r.path, "/") {
+			mux.Handle(r.path+"/", h)
+		}
 	}
+	return mux
+}
 
+func (server *Server) startHTTPAndHTTPS() (err error) {
 	allowedOrigins := strings.Split(server.corsDomain, ",")
-	handler := newCorsHandler(server.httpServer, allowedOrigins)
+	handler := newCorsHandler(server.newMux(func(r *route) http.Handler {
+		return r.httpServer
+	}), allowedOrigins)
 
 	server.httpListener, err = tmrpcserver.Listen(
 		server.rpcAddr, server.serverConfig)
@@ -196,14 +237,11 @@ func CreateCertificate(serverName string) *tls.Config {
 	return &tlsCfg
 }
 
-func (server *Server) startWSAndWSS(apis []gethrpc.API) (err error) {
-	server.wsServer = gethrpc.NewServer()
-	if err = registerApis(server.wsServer, server.wsAPIs, apis); err != nil {
-		return err
-	}
-
+func (server *Server) startWSAndWSS() (err error) {
 	allowedOrigins := strings.Split(server.corsDomain, ",")
-	wsh := server.wsServer.WebsocketHandler(allowedOrigins)
+	wsh := server.newMux(func(r *route) http.Handler {
+		return r.wsServer.WebsocketHandler(allowedOrigins)
+	})
 
 	server.wsListener, err = tmrpcserver.Listen(
 		server.wsAddr, server.serverConfig)
@@ -242,8 +280,10 @@ func (server *Server) OnStop() {
 }
 
 func (server *Server) stopHTTP() {
-	if server.httpServer != nil {
-		server.httpServer.Stop()
+	for _, r := range server.routes {
+		if r.httpServer != nil {
+			r.httpServer.Stop()
+		}
 	}
 	if server.httpListener != nil {
 		_ = server.httpListener.Close()
@@ -261,8 +301,10 @@ func (server *Server) stopHTTP() {
 }
 
 func (server *Server) stopWS() {
-	if server.wsServer != nil {
-		server.wsServer.Stop()
+	for _, r := range server.routes {
+		if r.wsServer != nil {
+			r.wsServer.Stop()
+		}
 	}
 	if server.wsListener != nil {
 		_ = server.wsListener.Close()
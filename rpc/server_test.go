@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type nameService struct {
+	name string
+}
+
+func (s *nameService) Name() string {
+	return s.name
+}
+
+func TestNewMux(t *testing.T) {
+	var routes []*route
+	for _, name := range []string{"bch", "ltc"} {
+		s := gethrpc.NewServer()
+		require.NoError(t, s.RegisterName("test", &nameService{name: name}))
+		routes = append(routes, &route{path: "/" + name, httpServer: s})
+	}
+	server := &Server{routes: routes}
+	httpServer := httptest.NewServer(server.newMux(func(r *route) http.Handler {
+		return r.httpServer
+	}))
+	defer httpServer.Close()
+
+	call := func(path string) (int, string) {
+		req := []byte(`{"jsonrpc":"2.0","id":1,"method":"test_name","params":[]}`)
+		resp, err := http.Post(httpServer.URL+path, "application/json", bytes.NewReader(req))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var result struct {
+			Result string `json:"result"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result.Result
+	}
+	code, name := call("/bch")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "bch", name)
+	code, name = call("/ltc/")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ltc", name)
+	code, _ = call("/doge")
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	modbtypes "github.com/smartbch/moeingdb/types"
+
+	"github.com/elfinguard/chainlogs/store"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+// MainChainBlockTxs is a main chain block and its EGTXs, which make up one virtual block in the main-chain-aligned mode
+type MainChainBlockTxs struct {
+	Height     int64
+	Hash       [32]byte
+	ParentHash [32]byte
+	Time       int64
+	Txs        []modbtypes.Tx // in the main chain order
+}
+
+// GetMainChainBlockTxs collects the EGTXs of the main chain block next to the latest scanned one, it returns nil
+// if that block does not have minConfirmations yet. The EGTXs are put into the virtual block at blockHeight,
+// whose hash is derived from the main chain block hash by deriveHash. The mempool is not checked, so adaptors
+// scanning the same main chain produce the same virtual blocks.
+func (b *BchScanner) GetMainChainBlockTxs(blockHeight, minConfirmations int64, deriveHash func(mainChainBlockHash [32]byte) [32]byte) (*MainChainBlockTxs, error) {
+	newestHeight, err := b.Client.GetBlockCount()
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblockcount", Err: err}
+	}
+	b.confirmations.setTip(newestHeight)
+	h := b.GetLatestScanHeight() + 1
+	if newestHeight-h+1 < minConfirmations || newestHeight < h {
+		return nil, nil
+	}
+	hash, err := b.Client.GetBlockHash(h)
+	if err == nil && hash == nil {
+		err = fmt.Errorf("block at height %d not found", h)
+	}
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblockhash", Err: err}
+	}
+	blk, err := b.Client.GetBlockVerboseTx(hash)
+	if err == nil && blk == nil {
+		err = fmt.Errorf("block %s not found", hash)
+	}
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblock", Err: err}
+	}
+	parentHash, err := chainhash.NewHashFromStr(blk.PreviousHash)
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblock", Err: err}
+	}
+	if prevBlk := b.Store.GetMainChainBlock(h - 1); prevBlk != nil && prevBlk.Hash != *parentHash {
+		// the virtual blocks built on the orphaned main chain blocks can not be replaced
+		return nil, &types.ReorgTooDeepError{Height: h - 1, MinConfirmations: minConfirmations}
+	}
+
+	res := MainChainBlockTxs{Height: h, Hash: *hash, ParentHash: *parentHash, Time: blk.Time}
+	virtualHash := deriveHash(*hash)
+	mainChainBlk := store.MainChainBlock{Hash: *hash}
+	for _, tx := range blk.Tx {
+		if b.quarantine.has(tx.Txid) {
+			continue
+		}
+		modbTx, err := b.convertUtxoInfoToTx(&tx, int64(len(res.Txs)), blockHeight, virtualHash)
+		if err != nil {
+			if err = b.onConvertError(err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		res.Txs = append(res.Txs, *modbTx)
+		mainChainBlk.Txids = append(mainChainBlk.Txids, common.HexToHash(tx.Txid))
+		b.confirmations.setMined(common.HexToHash(tx.Txid), h)
+	}
+	b.Store.SetMainChainBlock(h, &mainChainBlk)
+	b.SetLatestScanHeight(h)
+	b.logger.Debug("collect aligned main chain block txs", "height", h, "len(txs)", len(res.Txs))
+	return &res, nil
+}
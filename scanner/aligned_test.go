@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/chaincfg"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/smartbch/moeingevm/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+	chainlogstypes "github.com/elfinguard/chainlogs/types"
+)
+
+func newAlignedTestScanner() (*BchScanner, *bch.MockClient, *bch.TxRawResult) {
+	mc := &bch.MockClient{}
+	tx, _, _, _, _, _ := buildEGTx(mc)
+	fundingTx, _ := mc.GetRawTransactionVerbose(&chainhash.Hash{0x01})
+	mc.AddBlock(buildMainChainBlock(1, 0x11, 0x00, *fundingTx, *tx))
+	mc.AddBlock(buildMainChainBlock(2, 0x12, 0x11))
+	return &BchScanner{
+		Client:        mc,
+		Store:         &MockStore{},
+		MaxTxsInBlock: 1,
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		OutputParser:  bch.NewOutputParser(&chaincfg.MainNetParams),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		logger:        log.NewNopLogger(),
+	}, mc, tx
+}
+
+func TestBchScanner_mainChainAligned(t *testing.T) {
+	deriveHash := func(mainChainBlockHash [32]byte) [32]byte {
+		return sha256.Sum256(mainChainBlockHash[:])
+	}
+	b, mc, egtx := newAlignedTestScanner()
+	blk, err := b.GetMainChainBlockTxs(1, 2, deriveHash)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, blk.Height)
+	require.Equal(t, [32]byte(chainhash.Hash{0x11}), blk.Hash)
+	require.Equal(t, [32]byte{}, blk.ParentHash)
+	require.Len(t, blk.Txs, 1)
+	require.Equal(t, common.HexToHash(egtx.Txid), common.Hash(blk.Txs[0].HashId))
+	var tx types.Transaction
+	_, err = tx.UnmarshalMsg(blk.Txs[0].Content)
+	require.NoError(t, err)
+	require.Equal(t, deriveHash(blk.Hash), tx.BlockHash)
+	require.EqualValues(t, 1, tx.BlockNumber)
+
+	// block#2 has only one confirmation
+	blk2, err := b.GetMainChainBlockTxs(2, 2, deriveHash)
+	require.NoError(t, err)
+	require.Nil(t, blk2)
+	require.EqualValues(t, 1, b.GetLatestScanHeight())
+
+	// another adaptor produces the same block
+	other, _, _ := newAlignedTestScanner()
+	otherBlk, err := other.GetMainChainBlockTxs(1, 2, deriveHash)
+	require.NoError(t, err)
+	require.Equal(t, blk, otherBlk)
+
+	// empty blocks are aligned too
+	blk2, err = b.GetMainChainBlockTxs(2, 1, deriveHash)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, blk2.Height)
+	require.Len(t, blk2.Txs, 0)
+
+	// block#1 and block#2 are orphaned
+	mc.AddBlock(buildMainChainBlock(1, 0x21, 0x00))
+	mc.AddBlock(buildMainChainBlock(2, 0x22, 0x21))
+	mc.AddBlock(buildMainChainBlock(3, 0x23, 0x22))
+	blk3, err := b.GetMainChainBlockTxs(3, 1, deriveHash)
+	require.Nil(t, blk3)
+	var reorgErr *chainlogstypes.ReorgTooDeepError
+	require.ErrorAs(t, err, &reorgErr)
+	require.Equal(t, chainlogstypes.ReorgTooDeepError{Height: 2, MinConfirmations: 1}, *reorgErr)
+	require.EqualValues(t, 2, b.GetLatestScanHeight())
+}
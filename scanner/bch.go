@@ -7,12 +7,14 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/gcash/bchd/btcjson"
 	"github.com/gcash/bchd/chaincfg"
 	"github.com/gcash/bchd/chaincfg/chainhash"
-	"github.com/gcash/bchutil"
 	"github.com/holiman/uint256"
 	modbtypes "github.com/smartbch/moeingdb/types"
 	evmtypes "github.com/smartbch/moeingevm/types"
@@ -25,56 +27,94 @@ import (
 
 const MaxCacheSize = 100_000
 
+// RejectedTxTTL is how long a mempool tx which is not an EGTX is remembered in the store, the same as the
+// default mempool expiry of the nodes
+const RejectedTxTTL = 14 * 24 * time.Hour
+
 var _ IScanner = &BchScanner{}
 
 type BchScanner struct {
 	Client bch.IBchClient
 	Store  store.IStore
 
-	MaxTxsInBlock    int
-	OriginChainParam *chaincfg.Params
+	MaxTxsInBlock int
+	OutputParser  IOutputParser
+
+	LatestScanBlockHeight int64 // accessed atomically, GetScanStatus reads it from the rpc goroutines
+
+	knownTxCache *lru.Cache[string, bool] // non-EGTXs => false, collected EGTXs => true
+	reorgedTxs   map[[32]byte]struct{}    // EGTXs mined in orphaned main chain blocks, they are tracked again once re-mined
+	removedTxs   [][32]byte               // EGTXs removed by reorgs or dropped since last CollectRemovedTxs
+	push         *pushQueue               // EGTXs pushed through ZMQ, nil if only polling
+	quarantine   quarantine               // EGTXs which can not be derived
+	dropped      droppedTxs               // EGTXs double spent or evicted from the mempool
+	skipped      skippedBlocks            // main chain blocks the nodes do not reach quorum on
 
-	LatestScanBlockHeight int64
+	confirmations *confirmationTracker // nil if the confirmations are always asked from the node
 
-	knownTxCache map[string]struct{} // cache non-EGTXs and mined EGTXs
+	Prevouts *PrevoutResolver // created with the default settings if nil
 
 	logger log.Logger
 }
 
-func NewBchScanner(store store.IStore, mainChainClientInfo string, maxTxsInBlock int, logger log.Logger) *BchScanner {
+func NewBchScanner(store store.IStore, params *chaincfg.Params, mainChainClientInfos []string, maxTxsInBlock int, logger log.Logger) *BchScanner {
 	b := BchScanner{
-		Client:           bch.NewRetryableClient(mainChainClientInfo, 10, 999, logger.With("module", "client")),
-		Store:            store,
-		MaxTxsInBlock:    maxTxsInBlock,
-		OriginChainParam: &chaincfg.MainNetParams,
-		knownTxCache:     make(map[string]struct{}),
-		logger:           logger,
+		Client:        bch.NewMultiRetryableClient(mainChainClientInfos, 10, 999, logger.With("module", "client")),
+		Store:         store,
+		MaxTxsInBlock: maxTxsInBlock,
+		OutputParser:  bch.NewOutputParser(params),
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		confirmations: newConfirmationTracker(MaxCacheSize),
+		logger:        logger,
 	}
 	return &b
 }
 
 func (b *BchScanner) SetLatestScanHeight(blockHeight int64) {
-	b.LatestScanBlockHeight = blockHeight
+	atomic.StoreInt64(&b.LatestScanBlockHeight, blockHeight)
 }
 
 func (b *BchScanner) GetLatestScanHeight() int64 {
-	return b.LatestScanBlockHeight
+	return atomic.LoadInt64(&b.LatestScanBlockHeight)
 }
 
-func (b *BchScanner) GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock bool) []modbtypes.Tx {
+// GetNewTxs returns a *types.NodeError if the main chain node fails, and the EGTXs collected before the failure
+func (b *BchScanner) GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock bool) ([]modbtypes.Tx, error) {
 	var newModbTxs []modbtypes.Tx
 	txIndex := int64(0)
 	if scanBlock {
-		newModbTxs = b.collectMainChainBlockTxs(blockHeight, blockHash, &txIndex)
+		var err error
+		newModbTxs, err = b.collectMainChainBlockTxs(blockHeight, blockHash, &txIndex, 0)
+		if err != nil {
+			return newModbTxs, err
+		}
 	}
 	if len(newModbTxs) >= b.MaxTxsInBlock {
-		return newModbTxs
+		return newModbTxs, nil
 	}
-	txHashes, err := b.Client.GetRawMempool()
-	if err != nil {
-		panic(err)
+	txHashes, pushed := b.push.takeTxs()
+	if !pushed {
+		var err error
+		txHashes, err = b.Client.GetRawMempool()
+		if err != nil {
+			return newModbTxs, &types.NodeError{Method: "getrawmempool", Err: err}
+		}
+		b.logger.Debug("mempool info", "tx nums", len(txHashes))
+		b.checkLeftMempool(b.confirmations.refreshMempool(txHashes))
+	} else if scanBlock {
+		// the EGTXs leaving the mempool are not pushed, so the mempool is checked for them on new blocks
+		mempool, err := b.Client.GetRawMempool()
+		if err != nil {
+			return newModbTxs, &types.NodeError{Method: "getrawmempool", Err: err}
+		}
+		b.checkLeftMempool(b.confirmations.refreshMempool(mempool))
+	}
+	if scanBlock {
+		if n := b.Store.PruneRejectedTxs(time.Now().Unix()); n > 0 {
+			b.logger.Debug("prune rejected txs", "count", n)
+		}
 	}
-	b.logger.Debug("mempool info", "tx nums", len(txHashes))
 	for _, txHash := range txHashes {
 		// txHash.String() is the hexadecimal string of the txHash byte-reversed
 		txid := txHash.String()
@@ -90,57 +130,210 @@ func (b *BchScanner) GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock
 		}
 		modbTx, err := b.convertUtxoInfoToTx(tx, txIndex, blockHeight, blockHash)
 		if err != nil {
-			b.AddKnownTx(txid)
+			if err = b.onConvertError(err); err != nil {
+				if pushed {
+					b.push.setMissed()
+				}
+				return newModbTxs, err
+			}
+			b.AddKnownTx(txid, false)
+			if !b.quarantine.has(txid) {
+				b.Store.AddRejectedTx(common.HexToHash(txid), time.Now().Add(RejectedTxTTL).Unix())
+			}
 			continue
 		}
 		newModbTxs = append(newModbTxs, *modbTx)
-		b.AddKnownTx(txid)
+		b.AddKnownTx(txid, true)
+		b.confirmations.addPending(common.HexToHash(txid), txInputs(tx))
 		if len(newModbTxs) >= b.MaxTxsInBlock {
+			if pushed {
+				// the rest pushed EGTXs are found by polling the mempool in the next round
+				b.push.setMissed()
+			}
 			break
 		}
 	}
-	return newModbTxs
+	return newModbTxs, nil
+}
+
+// onConvertError quarantines the tx if err is a *types.TxError, and returns err if it is a *types.NodeError
+func (b *BchScanner) onConvertError(err error) error {
+	var nodeErr *types.NodeError
+	if errors.As(err, &nodeErr) {
+		return err
+	}
+	var txErr *types.TxError
+	if errors.As(err, &txErr) {
+		b.logger.Error("quarantine tx", "txid", txErr.Txid, "error", txErr.Err)
+		b.quarantine.add(txErr.Txid, txErr.Err)
+	}
+	return nil
 }
 
-func (b *BchScanner) collectMainChainBlockTxs(blockHeight int64, blockHash [32]byte, txIndex *int64) (newModbTxs []modbtypes.Tx) {
+// GetQuarantinedTxs returns the EGTXs skipped because they can not be derived
+func (b *BchScanner) GetQuarantinedTxs() []types.QuarantinedTx {
+	return b.quarantine.list()
+}
+
+// PrevoutStats returns the cache statistics of resolving the outputs spent by EGTXs
+func (b *BchScanner) PrevoutStats() types.PrevoutStats {
+	if b.Prevouts == nil {
+		return types.PrevoutStats{}
+	}
+	return b.Prevouts.Stats()
+}
+
+// GetBlockTxs collects the EGTXs in the main chain blocks after the latest scanned one up to toHeight, without
+// looking at the mempool. It is used to backfill the history.
+func (b *BchScanner) GetBlockTxs(blockHeight int64, blockHash [32]byte, toHeight int64) ([]modbtypes.Tx, error) {
+	var txIndex int64
+	return b.collectMainChainBlockTxs(blockHeight, blockHash, &txIndex, toHeight)
+}
+
+// collectMainChainBlockTxs scans the main chain blocks up to toHeight, or the newest one if toHeight is 0.
+// It only returns the EGTXs in the fully scanned blocks if it fails.
+func (b *BchScanner) collectMainChainBlockTxs(blockHeight int64, blockHash [32]byte, txIndex *int64, toHeight int64) ([]modbtypes.Tx, error) {
+	var newModbTxs []modbtypes.Tx
 	newestHeight, err := b.Client.GetBlockCount()
 	if err != nil {
-		panic(err)
+		return nil, &types.NodeError{Method: "getblockcount", Err: err}
 	}
-	for h := b.LatestScanBlockHeight + 1; h <= newestHeight; h++ {
+	b.confirmations.setTip(newestHeight)
+	endHeight := newestHeight
+	if toHeight > newestHeight {
+		return nil, fmt.Errorf("height %d is beyond the main chain tip %d", toHeight, newestHeight)
+	} else if toHeight > 0 {
+		endHeight = toHeight
+	}
+	for h := b.GetLatestScanHeight() + 1; h <= endHeight; h++ {
+		// a disagreement on the block hash is retried, as the nodes at the tip may not see the new block yet
 		hash, err := b.Client.GetBlockHash(h)
+		if err == nil && hash == nil {
+			err = fmt.Errorf("block at height %d not found", h)
+		}
 		if err != nil {
-			panic(err)
+			return newModbTxs, &types.NodeError{Method: "getblockhash", Err: err}
 		}
 		blk, err := b.Client.GetBlockVerboseTx(hash)
+		if errors.Is(err, bch.ErrNoQuorum) {
+			b.skipBlock(h, *hash, err)
+			continue
+		}
+		if err == nil && blk == nil {
+			err = fmt.Errorf("block %s not found", hash)
+		}
+		if err != nil {
+			return newModbTxs, &types.NodeError{Method: "getblock", Err: err}
+		}
+		forkHeight, reorged, err := b.checkReorg(h, blk.PreviousHash)
 		if err != nil {
-			panic(err)
+			return newModbTxs, err
+		}
+		if reorged {
+			// rescan from the fork point
+			b.SetLatestScanHeight(forkHeight)
+			if forkBlk := b.Store.GetMainChainBlock(forkHeight); forkBlk != nil {
+				b.Store.SetScanCheckpoint(&store.ScanCheckpoint{Height: forkHeight, Hash: forkBlk.Hash})
+			}
+			h = forkHeight
+			continue
 		}
 		b.logger.Debug("collect main chain block txs", "height", h, "len(txs)", len(blk.Tx))
+		mainChainBlk := store.MainChainBlock{Hash: *hash}
+		blkStart, txIndexStart := len(newModbTxs), *txIndex
 		for _, tx := range blk.Tx {
-			if b.isKnownTx(tx.Txid) {
+			txHash := common.HexToHash(tx.Txid)
+			// a tx mined again after a reorg keeps its stored copy, it is only collected again if it was never stored
+			_, reorged := b.reorgedTxs[txHash]
+			if !reorged && b.isCachedNonEGTX(tx.Txid) {
 				continue
-			}
-			if b.Store.IsTxMined(tx.Txid) {
+			} else if b.Store.IsTxMined(tx.Txid) {
+				delete(b.reorgedTxs, txHash)
+				mainChainBlk.Txids = append(mainChainBlk.Txids, txHash)
+				b.confirmations.setMined(txHash, h)
+				continue
+			} else if !reorged && b.isKnownTx(tx.Txid) || b.quarantine.has(tx.Txid) {
 				continue
 			}
 			modbTx, err := b.convertUtxoInfoToTx(&tx, *txIndex, blockHeight, blockHash)
 			if err != nil {
+				if err = b.onConvertError(err); err != nil {
+					// rescan this block next time
+					*txIndex = txIndexStart
+					return newModbTxs[:blkStart], err
+				}
 				// no need to add already mined tx in main chain block
 				//b.AddKnownTx(tx.Txid)
 				continue
 			}
+			delete(b.reorgedTxs, txHash)
 			newModbTxs = append(newModbTxs, *modbTx)
+			mainChainBlk.Txids = append(mainChainBlk.Txids, txHash)
+			b.confirmations.setMined(txHash, h)
 			//b.AddKnownTx(tx.Txid)
 			*txIndex++
 		}
+		b.Store.SetMainChainBlock(h, &mainChainBlk)
 		b.SetLatestScanHeight(h)
+		if len(newModbTxs) == 0 {
+			// no collected EGTX is waiting to be stored, so the scan can resume after this block
+			b.Store.SetScanCheckpoint(&store.ScanCheckpoint{Height: h, Hash: *hash})
+		}
 		// allow nums of EGTX bigger than config only in situation which there has more EGTX in current main chain block.
 		if len(newModbTxs) >= b.MaxTxsInBlock {
-			return
+			return newModbTxs, nil
+		}
+	}
+	return newModbTxs, nil
+}
+
+// checkReorg compares the parent of the main chain block at height with the block scanned at height-1.
+// If they mismatch, it walks back to the fork point and marks the EGTXs mined in the orphaned blocks as removed.
+func (b *BchScanner) checkReorg(height int64, parentHash string) (forkHeight int64, reorged bool, err error) {
+	prevBlk := b.Store.GetMainChainBlock(height - 1)
+	if prevBlk == nil {
+		return 0, false, nil
+	}
+	parent, err := chainhash.NewHashFromStr(parentHash)
+	if err != nil {
+		return 0, false, &types.NodeError{Method: "getblock", Err: err}
+	}
+	if *parent == prevBlk.Hash {
+		return 0, false, nil
+	}
+	removedCount := 0
+	for forkHeight = height - 1; forkHeight > 0; forkHeight-- {
+		orphanBlk := b.Store.GetMainChainBlock(forkHeight)
+		if orphanBlk == nil {
+			break
 		}
+		hash, err := b.Client.GetBlockHash(forkHeight)
+		if err == nil && hash == nil {
+			err = fmt.Errorf("block at height %d not found", forkHeight)
+		}
+		if err != nil {
+			return 0, false, &types.NodeError{Method: "getblockhash", Err: err}
+		}
+		if *hash == orphanBlk.Hash {
+			break
+		}
+		for _, txid := range orphanBlk.Txids {
+			b.reorgedTxs[txid] = struct{}{}
+			b.removedTxs = append(b.removedTxs, txid)
+			b.confirmations.setOrphaned(txid)
+		}
+		removedCount += len(orphanBlk.Txids)
+		b.Store.DeleteMainChainBlock(forkHeight)
 	}
-	return
+	b.logger.Info("main chain reorg detected", "height", height, "forkHeight", forkHeight, "removedTxs", removedCount)
+	return forkHeight, true, nil
+}
+
+// CollectRemovedTxs returns the EGTXs removed by main chain reorgs or dropped from the mempool since the last call
+func (b *BchScanner) CollectRemovedTxs() [][32]byte {
+	removedTxs := b.removedTxs
+	b.removedTxs = nil
+	return removedTxs
 }
 
 func buildTokenInfo(address []byte, tokenData btcjson.TokenDataResult) (bch.TokenInfo, error) {
@@ -193,7 +386,7 @@ func buildTokenInfo(address []byte, tokenData btcjson.TokenDataResult) (bch.Toke
 	return tokenInfo, nil
 }
 
-func (b *BchScanner) convertUtxoInfoToTx(tx *btcjson.TxRawResult, txIndex, blockHeight int64, blockHash [32]byte) (*modbtypes.Tx, error) {
+func (b *BchScanner) convertUtxoInfoToTx(tx *bch.TxRawResult, txIndex, blockHeight int64, blockHash [32]byte) (*modbtypes.Tx, error) {
 	var nullData string
 	var receiverInfos [][32]byte
 	var dstAddr [20]byte
@@ -210,21 +403,14 @@ func (b *BchScanner) convertUtxoInfoToTx(tx *btcjson.TxRawResult, txIndex, block
 			}
 			nullData = vout.ScriptPubKey.Hex[len(bch.EGTXFlag):]
 		}
-		if i == 1 && vout.ScriptPubKey.Type != "pubkeyhash" && vout.ScriptPubKey.Type != "scripthash" {
+		if i == 1 && !b.OutputParser.IsAddressOutput(&vout.ScriptPubKey) {
 			return nil, types.SecondOutputInvalid
 		}
-		if vout.ScriptPubKey.Type == "pubkeyhash" || vout.ScriptPubKey.Type == "scripthash" {
-			if len(vout.ScriptPubKey.Addresses) != 1 {
-				panic(types.PubkeyScriptAddressNumInvalid.Error())
-			}
-			var receiverInfo [32]byte
-			address, err := bchutil.DecodeAddress(vout.ScriptPubKey.Addresses[0], b.OriginChainParam)
+		if b.OutputParser.IsAddressOutput(&vout.ScriptPubKey) {
+			receiverInfo, err := b.OutputParser.ExtractOutputInfo(&vout)
 			if err != nil {
-				panic(err)
+				return nil, &types.TxError{Txid: tx.Txid, Err: err}
 			}
-			copy(receiverInfo[:20], address.ScriptAddress())
-			amount := uint256.NewInt(0).Mul(uint256.NewInt(uint64(vout.Value*1e8)), uint256.NewInt(1e10)).Bytes20()
-			copy(receiverInfo[20:], amount[8:])
 			receiverInfos = append(receiverInfos, receiverInfo)
 			tokenInfo, err := buildTokenInfo(receiverInfo[:20], vout.TokenData)
 			if err != nil {
@@ -242,7 +428,10 @@ func (b *BchScanner) convertUtxoInfoToTx(tx *btcjson.TxRawResult, txIndex, block
 			}
 		}
 	}
-	senderInfos, inputTokenInfos := b.extractInputInfos(tx) // list of [20byte address + 12byte value]
+	senderInfos, inputTokenInfos, err := b.extractInputInfos(tx) // list of [20byte address + 12byte value]
+	if err != nil {
+		return nil, err
+	}
 	if len(senderInfos) != 0 {
 		copy(srcAddr[:], senderInfos[0][:20])
 	}
@@ -251,7 +440,7 @@ func (b *BchScanner) convertUtxoInfoToTx(tx *btcjson.TxRawResult, txIndex, block
 	}
 	contractAddress, topics, otherData, err := bch.ExtractEGTXNullData(nullData)
 	if err != nil {
-		return nil, err
+		return nil, &types.TxError{Txid: tx.Txid, Err: err}
 	}
 	otherData = append(otherData, otherNullDatas...)
 	data := bch.BuildLogData(uint256.NewInt(0), receiverInfos, senderInfos, outputTokenInfos, inputTokenInfos, otherData)
@@ -284,6 +473,7 @@ func (b *BchScanner) convertUtxoInfoToTx(tx *btcjson.TxRawResult, txIndex, block
 				BlockHash:   blockHash,
 			}},
 	}
+	evmTx.LogsBloom = types.CreateBloom([]modbtypes.Tx{modbTx})
 	txContent, err := evmTx.MarshalMsg(nil)
 	if err != nil {
 		panic(err)
@@ -293,56 +483,83 @@ func (b *BchScanner) convertUtxoInfoToTx(tx *btcjson.TxRawResult, txIndex, block
 	return &modbTx, nil
 }
 
-func (b *BchScanner) AddKnownTx(txHash string) {
-	if len(b.knownTxCache) > MaxCacheSize {
-		for tx := range b.knownTxCache { // random evict
-			delete(b.knownTxCache, tx)
-			break
-		}
-	}
-	b.knownTxCache[txHash] = struct{}{}
+// AddKnownTx caches a collected EGTX if isEGTX, otherwise a tx which is not derivable
+func (b *BchScanner) AddKnownTx(txHash string, isEGTX bool) {
+	b.knownTxCache.Add(txHash, isEGTX)
 }
 
+// isKnownTx also looks up the rejected txs in the store, which survive restarts and cache evictions
 func (b *BchScanner) isKnownTx(txHash string) bool {
-	_, exist := b.knownTxCache[txHash]
-	return exist
+	if b.knownTxCache.Contains(txHash) {
+		return true
+	}
+	if b.Store.IsTxRejected(common.HexToHash(txHash)) {
+		b.knownTxCache.Add(txHash, false)
+		return true
+	}
+	return false
+}
+
+// isCachedNonEGTX only looks up the cache, so the txs of main chain blocks are mostly skipped without store lookups
+func (b *BchScanner) isCachedNonEGTX(txHash string) bool {
+	isEGTX, ok := b.knownTxCache.Get(txHash)
+	return ok && !isEGTX
+}
+
+// MainChainEndpoints returns nil if the client does not connect to several nodes, such as a mock client
+func (b *BchScanner) MainChainEndpoints() []bch.EndpointStatus {
+	if c, ok := b.Client.(bch.IEndpointReporter); ok {
+		return c.EndpointStatuses()
+	}
+	return nil
 }
 
 // Getconfirmations return value:
 // when tx mined in virtual chain, its finalize number is 0,
 // when it mined in source chain the latest block, its finalize number is 1,
 // when it not is mined by source chain and is not in mempool either, we think one or more of its inputs have been spent by other tx, so its finalize number is -1.
+// GetConfirmations answers from the tracked EGTXs, only the untracked ones are asked from the node
 func (b *BchScanner) GetConfirmations(txHash [32]byte) int32 {
 	hash, err := chainhash.NewHash(txHash[:])
 	if err != nil {
 		panic(err)
 	}
+	trackedHash := common.HexToHash(hash.String())
+	if c, ok := b.confirmations.get(trackedHash); ok {
+		return c
+	}
 	res, err := b.Client.GetRawTransactionVerbose(hash)
 	if err != nil {
 		return -1
 	}
 	c := int32(res.Confirmations)
+	if tip := b.confirmations.getTip(); c > 0 && tip > 0 {
+		b.confirmations.setMined(trackedHash, tip-int64(c)+1)
+	}
 	return c
 }
 
-func (b *BchScanner) extractInputInfos(tx *btcjson.TxRawResult) ([][32]byte, []bch.TokenInfo) {
+// extractInputInfos only collects the inputs spending address outputs
+func (b *BchScanner) extractInputInfos(tx *bch.TxRawResult) ([][32]byte, []bch.TokenInfo, error) {
+	if b.Prevouts == nil {
+		b.Prevouts = NewPrevoutResolver(b.Client, DefaultPrevoutWorkers, DefaultPrevoutCacheSize)
+	}
+	prevouts, err := b.Prevouts.Resolve(tx)
+	if err != nil {
+		return nil, nil, err
+	}
 	var senderInfos [][32]byte
 	var tokenInfos []bch.TokenInfo
-	for _, vin := range tx.Vin {
-		txHash, err := chainhash.NewHashFromStr(vin.Txid)
-		if err != nil {
-			panic(err)
-		}
-		originTx, err := b.Client.GetRawTransactionVerbose(txHash)
-		if err != nil {
-			panic(err)
+	for _, prevout := range prevouts {
+		if prevout == nil || !b.OutputParser.IsAddressOutput(&prevout.ScriptPubKey) {
+			continue
 		}
-		senderInfo, err := bch.ExtractSenderInfo(originTx, vin.Vout, b.OriginChainParam)
+		senderInfo, err := b.OutputParser.ExtractOutputInfo(prevout)
 		if err != nil {
-			panic(err)
+			return nil, nil, &types.TxError{Txid: tx.Txid, Err: err}
 		}
 		senderInfos = append(senderInfos, senderInfo)
-		tokenInfo, err := buildTokenInfo(senderInfo[:20], originTx.Vout[vin.Vout].TokenData)
+		tokenInfo, err := buildTokenInfo(senderInfo[:20], prevout.TokenData)
 		if err != nil {
 			// todo: panic or not
 			fmt.Println("buildTokenInfo err:" + err.Error())
@@ -350,5 +567,5 @@ func (b *BchScanner) extractInputInfos(tx *btcjson.TxRawResult) ([][32]byte, []b
 		}
 		tokenInfos = append(tokenInfos, tokenInfo)
 	}
-	return senderInfos, tokenInfos
+	return senderInfos, tokenInfos, nil
 }
@@ -3,10 +3,13 @@ package scanner
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/gcash/bchd/btcjson"
 	"github.com/gcash/bchd/chaincfg"
 	"github.com/gcash/bchd/chaincfg/chainhash"
@@ -18,6 +21,8 @@ import (
 	"github.com/smartbch/moeingevm/types"
 
 	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/store"
+	chainlogstypes "github.com/elfinguard/chainlogs/types"
 )
 
 func TestBchScanner(t *testing.T) {
@@ -34,9 +39,10 @@ func TestBchScanner(t *testing.T) {
 			timestamp:     0,
 			latestBlkHash: [32]byte{},
 		},
-		MaxTxsInBlock:    1,
-		OriginChainParam: &chaincfg.MainNetParams,
-		logger:           log.NewNopLogger(),
+		MaxTxsInBlock: 1,
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		OutputParser:  bch.NewOutputParser(&chaincfg.MainNetParams),
+		logger:        log.NewNopLogger(),
 	}
 	tx, contractAddress, payer, payee, fileID, data := buildEGTx(mc)
 	blkHash := [32]byte{0x1}
@@ -65,18 +71,155 @@ func TestBchScanner(t *testing.T) {
 	require.True(t, bytes.Equal(data[:], lg.Data[len(lg.Data)-32:]))
 }
 
-func buildEGTx(m *bch.MockClient) (*btcjson.TxRawResult, [20]byte, [20]byte, [20]byte, [32]byte, [32]byte) {
+func TestBchScanner_reorg(t *testing.T) {
+	mc := &bch.MockClient{}
+	b := BchScanner{
+		Client:        mc,
+		Store:         &MockStore{},
+		MaxTxsInBlock: 100,
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		OutputParser:  bch.NewOutputParser(&chaincfg.MainNetParams),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		logger:        log.NewNopLogger(),
+	}
+	tx, _, _, _, _, _ := buildEGTx(mc)
+	mc.AddBlock(buildMainChainBlock(1, 0x11, 0x00, *tx))
+	mc.AddBlock(buildMainChainBlock(2, 0x12, 0x11))
+	var txIndex int64
+	txs, err := b.collectMainChainBlockTxs(1, [32]byte{0x01}, &txIndex, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, int64(2), b.GetLatestScanHeight())
+	require.Len(t, b.CollectRemovedTxs(), 0)
+	b.Store.AddBlock(&modbtypes.Block{Height: 1, BlockHash: [32]byte{0x01}, TxList: txs})
+
+	// block#1 and block#2 are orphaned, the EGTX is not in the new chain
+	mc.AddBlock(buildMainChainBlock(1, 0x21, 0x00))
+	mc.AddBlock(buildMainChainBlock(2, 0x22, 0x21))
+	mc.AddBlock(buildMainChainBlock(3, 0x23, 0x22))
+	txIndex = 0
+	txs, err = b.collectMainChainBlockTxs(2, [32]byte{0x02}, &txIndex, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 0)
+	require.Equal(t, int64(3), b.GetLatestScanHeight())
+	removedTxs := b.CollectRemovedTxs()
+	require.Len(t, removedTxs, 1)
+	require.Equal(t, common.HexToHash(tx.Txid), common.Hash(removedTxs[0]))
+	require.Len(t, b.CollectRemovedTxs(), 0)
+
+	// block#3 is orphaned, the EGTX is mined again in the new chain
+	mc.AddBlock(buildMainChainBlock(3, 0x33, 0x22, *tx))
+	mc.AddBlock(buildMainChainBlock(4, 0x34, 0x33))
+	txIndex = 0
+	txs, err = b.collectMainChainBlockTxs(3, [32]byte{0x03}, &txIndex, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 0)
+	require.Equal(t, int64(4), b.GetLatestScanHeight())
+	require.Len(t, b.CollectRemovedTxs(), 0)
+	require.Equal(t, [][32]byte{common.HexToHash(tx.Txid)}, b.Store.GetMainChainBlock(3).Txids)
+	// the stored copy is kept, so the tx is found in its first virtual block
+	storedTx, _, err := b.Store.GetTxByHash(common.HexToHash(tx.Txid))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, storedTx.BlockNumber)
+	require.Empty(t, b.reorgedTxs)
+
+	// a re-mined tx which was never stored is collected again
+	delete(b.Store.(*MockStore).txByHash, common.HexToHash(tx.Txid).Hex())
+	b.reorgedTxs[common.HexToHash(tx.Txid)] = struct{}{}
+	b.Store.DeleteMainChainBlock(3)
+	b.Store.DeleteMainChainBlock(4)
+	b.SetLatestScanHeight(2)
+	txIndex = 0
+	txs, err = b.collectMainChainBlockTxs(4, [32]byte{0x04}, &txIndex, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, common.HexToHash(tx.Txid), common.Hash(txs[0].HashId))
+}
+
+func TestBchScanner_reorgBlockNotFound(t *testing.T) {
+	b := BchScanner{
+		Client:     &bch.MockClient{},
+		Store:      &MockStore{},
+		reorgedTxs: make(map[[32]byte]struct{}),
+		logger:     log.NewNopLogger(),
+	}
+	b.Store.SetMainChainBlock(1, &store.MainChainBlock{Hash: chainhash.Hash{0x11}})
+	_, _, err := b.checkReorg(2, chainhash.Hash{0x21}.String())
+	var nodeErr *chainlogstypes.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+	require.NotNil(t, b.Store.GetMainChainBlock(1))
+}
+
+func TestBchScanner_errors(t *testing.T) {
+	mc := &bch.MockClient{}
+	b := BchScanner{
+		Client:        mc,
+		Store:         &MockStore{},
+		MaxTxsInBlock: 100,
+		OutputParser:  bch.NewOutputParser(&chaincfg.MainNetParams),
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		logger:        log.NewNopLogger(),
+	}
+	tx, _, _, _, _, _ := buildEGTx(mc)
+	// more than one address in the second output
+	tx.Vout[1].ScriptPubKey.Addresses = append(tx.Vout[1].ScriptPubKey.Addresses, tx.Vout[1].ScriptPubKey.Addresses[0])
+	txs, err := b.GetNewTxs(1, [32]byte{0x01}, false)
+	require.NoError(t, err)
+	require.Len(t, txs, 0)
+	quarantined := b.GetQuarantinedTxs()
+	require.Len(t, quarantined, 1)
+	require.Equal(t, tx.Txid, quarantined[0].Txid)
+	require.Equal(t, chainlogstypes.PubkeyScriptAddressNumInvalid.Error(), quarantined[0].Reason)
+
+	// the quarantined tx is skipped when it is mined
+	mc.AddBlock(buildMainChainBlock(1, 0x11, 0x00, *tx))
+	txs, err = b.GetNewTxs(2, [32]byte{0x02}, true)
+	require.NoError(t, err)
+	require.Len(t, txs, 0)
+	require.Equal(t, int64(1), b.GetLatestScanHeight())
+
+	// the node is down
+	mc.AddBlock(buildMainChainBlock(2, 0x12, 0x11))
+	mc.SetError(errors.New("connection refused"))
+	_, err = b.GetNewTxs(3, [32]byte{0x03}, true)
+	var nodeErr *chainlogstypes.NodeError
+	require.True(t, errors.As(err, &nodeErr))
+	require.Equal(t, "getblockcount", nodeErr.Method)
+	require.Equal(t, int64(1), b.GetLatestScanHeight())
+
+	mc.SetError(nil)
+	_, err = b.GetNewTxs(3, [32]byte{0x03}, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), b.GetLatestScanHeight())
+}
+
+func buildMainChainBlock(height int64, hash, parentHash byte, txs ...bch.TxRawResult) *bch.GetBlockVerboseTxResult {
+	var h, p chainhash.Hash
+	h[0] = hash
+	p[0] = parentHash
+	return &bch.GetBlockVerboseTxResult{
+		GetBlockVerboseTxResult: btcjson.GetBlockVerboseTxResult{
+			Hash:         h.String(),
+			Height:       height,
+			PreviousHash: p.String(),
+		},
+		Tx: txs,
+	}
+}
+
+func buildEGTx(m *bch.MockClient) (*bch.TxRawResult, [20]byte, [20]byte, [20]byte, [32]byte, [32]byte) {
 	payer := [20]byte{0x02}
 	tx0Hash := [32]byte{0x01}
-	tx0 := btcjson.TxRawResult{
+	tx0 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{
 		Txid: hex.EncodeToString(tx0Hash[:]),
 		Hash: hex.EncodeToString(tx0Hash[:]),
-	}
-	vout := btcjson.Vout{
-		Value: 1,
-		ScriptPubKey: btcjson.ScriptPubKeyResult{
+	}}
+	vout := bch.Vout{
+		Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
 			Type: "pubkeyhash",
-		},
+		}},
+		Value: 1e8,
 	}
 	addressPubkeyHash, _ := bchutil.NewAddressPubKeyHash(payer[:], &chaincfg.MainNetParams)
 	vout.ScriptPubKey.Addresses = append(vout.ScriptPubKey.Addresses, addressPubkeyHash.EncodeAddress())
@@ -87,18 +230,18 @@ func buildEGTx(m *bch.MockClient) (*btcjson.TxRawResult, [20]byte, [20]byte, [20
 	m.AddTx(tx0H, &tx0)
 
 	tx1Hash := [32]byte{0x02}
-	tx1 := btcjson.TxRawResult{
+	tx1 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{
 		Txid: hex.EncodeToString(tx1Hash[:]),
 		Hash: hex.EncodeToString(tx1Hash[:]),
-	}
+	}}
 	vin := btcjson.Vin{Txid: tx0H.String()}
 	tx1.Vin = append(tx1.Vin, vin)
-	vout = btcjson.Vout{
+	vout = bch.Vout{Vout: btcjson.Vout{
 		ScriptPubKey: btcjson.ScriptPubKeyResult{
 			Asm:  "OP_RETURN EGTX",
 			Type: "nulldata",
 		},
-	}
+	}}
 	var contractAddress = [20]byte{0x01}
 	var payee = [20]byte{0x03}
 	var fileID = [32]byte{0x01}
@@ -114,11 +257,11 @@ func buildEGTx(m *bch.MockClient) (*btcjson.TxRawResult, [20]byte, [20]byte, [20
 		AddData(data[:]).Script()
 	vout.ScriptPubKey.Hex = hex.EncodeToString(script)
 	tx1.Vout = append(tx1.Vout, vout)
-	vout = btcjson.Vout{
-		Value: 1,
-		ScriptPubKey: btcjson.ScriptPubKeyResult{
+	vout = bch.Vout{
+		Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
 			Type: "pubkeyhash",
-		},
+		}},
+		Value: 1e8,
 	}
 	addressPubkeyHash, _ = bchutil.NewAddressPubKeyHash(payee[:], &chaincfg.MainNetParams)
 	vout.ScriptPubKey.Addresses = append(vout.ScriptPubKey.Addresses, addressPubkeyHash.EncodeAddress())
@@ -0,0 +1,31 @@
+package scanner
+
+import (
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/btc"
+	"github.com/elfinguard/chainlogs/store"
+)
+
+var _ IScanner = &BtcScanner{}
+
+// BtcScanner scans Bitcoin's mempool and blocks, it reuses BchScanner with an output parser supporting segwit and taproot
+type BtcScanner struct {
+	*BchScanner
+}
+
+func NewBtcScanner(store store.IStore, mainChainClientInfos []string, maxTxsInBlock int, logger log.Logger) *BtcScanner {
+	b := BchScanner{
+		Client:        bch.NewMultiRetryableClient(mainChainClientInfos, 10, 999, logger.With("module", "client")),
+		Store:         store,
+		MaxTxsInBlock: maxTxsInBlock,
+		OutputParser:  btc.NewOutputParser(),
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		confirmations: newConfirmationTracker(MaxCacheSize),
+		logger:        logger,
+	}
+	return &BtcScanner{BchScanner: &b}
+}
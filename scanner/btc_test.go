@@ -0,0 +1,81 @@
+package scanner
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/txscript"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/btc"
+)
+
+func TestBtcScanner(t *testing.T) {
+	mc := &bch.MockClient{}
+	b := BtcScanner{&BchScanner{
+		Client:        mc,
+		Store:         &MockStore{},
+		MaxTxsInBlock: 1,
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		OutputParser:  btc.NewOutputParser(),
+		logger:        log.NewNopLogger(),
+	}}
+
+	// the sender spends a P2TR output
+	taprootKey := [32]byte{0x02}
+	tx0Hash := [32]byte{0x01}
+	tx0 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hex.EncodeToString(tx0Hash[:])}}
+	tx0.Vout = append(tx0.Vout, buildBtcVout(2e8, "witness_v1_taproot", append([]byte{txscript.OP_1, txscript.OP_DATA_32}, taprootKey[:]...)))
+	tx0H, _ := chainhash.NewHash(tx0Hash[:])
+	mc.AddTx(tx0H, &tx0)
+
+	// pays to a P2WPKH output
+	contractAddress := [20]byte{0x01}
+	payee := [20]byte{0x03}
+	tx1 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hex.EncodeToString([]byte{0x02})}}
+	tx1.Vin = append(tx1.Vin, btcjson.Vin{Txid: tx0H.String()})
+	script, _ := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_RETURN).
+		AddData([]byte("EGTX")).
+		AddData(contractAddress[:]).Script()
+	tx1.Vout = append(tx1.Vout, buildBtcVout(0, "nulldata", script))
+	tx1.Vout = append(tx1.Vout, buildBtcVout(1e8, "witness_v0_keyhash", append([]byte{txscript.OP_0, txscript.OP_DATA_20}, payee[:]...)))
+
+	mTx, err := b.convertUtxoInfoToTx(&tx1, 0, 1, [32]byte{0x01})
+	require.NoError(t, err)
+	payer, err := bch.ConvertWitnessProgramToAddress(taprootKey[:])
+	require.NoError(t, err)
+	require.Equal(t, payer, mTx.SrcAddr)
+	require.Equal(t, payee, mTx.DstAddr)
+
+	var originTx types.Transaction
+	_, err = originTx.UnmarshalMsg(mTx.Content)
+	require.NoError(t, err)
+	res, err := bch.UnPackEGTXLog(originTx.Logs[0].Data)
+	require.NoError(t, err)
+	outputs := res[1].([]*big.Int)
+	require.Len(t, outputs, 1)
+	require.Equal(t, bch.PackAddressAndValue(payee[:], 1e8), to32Bytes(outputs[0]))
+	inputs := res[2].([]*big.Int)
+	require.Len(t, inputs, 1)
+	require.Equal(t, bch.PackAddressAndValue(payer[:], 2e8), to32Bytes(inputs[0]))
+}
+
+// Bitcoin Core (v22+) does not return 'addresses' in scriptPubKey
+func buildBtcVout(value bch.Amount, scriptType string, script []byte) bch.Vout {
+	return bch.Vout{
+		Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
+			Type: scriptType,
+			Hex:  hex.EncodeToString(script),
+		}},
+		Value: value,
+	}
+}
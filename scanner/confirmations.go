@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+)
+
+// confirmationTracker answers the confirmations of EGTXs from memory. It records the main chain height every
+// EGTX is mined at while the blocks are scanned, and the EGTXs still in the mempool. A nil tracker tracks nothing.
+type confirmationTracker struct {
+	mtx     sync.RWMutex
+	tip     int64                          // the newest main chain height
+	mined   *lru.Cache[common.Hash, int64] // txHash => the main chain height mined at
+	pending map[common.Hash][]outpoint     // EGTXs in the mempool => their inputs
+}
+
+// newConfirmationTracker keeps at most size mined EGTXs, the confirmations of the evicted ones are asked from the node
+func newConfirmationTracker(size int) *confirmationTracker {
+	return &confirmationTracker{
+		mined:   lru.NewCache[common.Hash, int64](size),
+		pending: make(map[common.Hash][]outpoint),
+	}
+}
+
+// txHash of the methods below is common.HexToHash(txid)
+
+func (t *confirmationTracker) setTip(height int64) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.tip = height
+}
+
+// addPending tracks an EGTX in the mempool, its inputs tell whether it is double spent once it leaves the mempool
+func (t *confirmationTracker) addPending(txHash common.Hash, inputs []outpoint) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.pending[txHash] = inputs
+}
+
+func (t *confirmationTracker) setMined(txHash common.Hash, height int64) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	delete(t.pending, txHash)
+	t.mined.Add(txHash, height)
+}
+
+// setOrphaned moves an EGTX mined in an orphaned block back to the mempool, until it is mined again or evicted
+func (t *confirmationTracker) setOrphaned(txHash common.Hash) {
+	if t == nil {
+		return
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.mined.Remove(txHash)
+	t.pending[txHash] = nil
+}
+
+// refreshMempool returns and forgets the pending EGTXs which are not in the mempool any more. They may be mined
+// in a block which is not scanned yet, or be dropped.
+func (t *confirmationTracker) refreshMempool(mempool []*chainhash.Hash) (left map[common.Hash][]outpoint) {
+	if t == nil {
+		return nil
+	}
+	inMempool := make(map[common.Hash]struct{}, len(mempool))
+	for _, txHash := range mempool {
+		inMempool[common.HexToHash(txHash.String())] = struct{}{}
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	left = make(map[common.Hash][]outpoint)
+	for txHash, inputs := range t.pending {
+		if _, ok := inMempool[txHash]; !ok {
+			left[txHash] = inputs
+			delete(t.pending, txHash)
+		}
+	}
+	return
+}
+
+// get returns false if the EGTX is not tracked
+func (t *confirmationTracker) get(txHash common.Hash) (int32, bool) {
+	if t == nil {
+		return 0, false
+	}
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	if _, ok := t.pending[txHash]; ok {
+		return 0, true
+	}
+	if height, ok := t.mined.Get(txHash); ok && height <= t.tip {
+		return int32(t.tip - height + 1), true
+	}
+	return 0, false
+}
+
+// getTip returns 0 if no block is scanned yet
+func (t *confirmationTracker) getTip() int64 {
+	if t == nil {
+		return 0
+	}
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.tip
+}
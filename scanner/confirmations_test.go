@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+func TestBchScanner_GetConfirmations(t *testing.T) {
+	mc := &prevoutTestClient{MockClient: &bch.MockClient{}}
+	hashA, hashB, hashC := chainhash.Hash{0xa}, chainhash.Hash{0xb}, chainhash.Hash{0xc}
+	mc.AddTx(&hashB, &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hashB.String(), Confirmations: 2}})
+	b := &BchScanner{Client: mc, confirmations: newConfirmationTracker(10)}
+	tracked := func(h chainhash.Hash) common.Hash {
+		return common.HexToHash(h.String())
+	}
+
+	b.confirmations.setTip(10)
+	b.confirmations.setMined(tracked(hashA), 8)
+	b.confirmations.addPending(tracked(hashB), nil)
+	require.EqualValues(t, 3, b.GetConfirmations(hashA))
+	require.EqualValues(t, 0, b.GetConfirmations(hashB))
+	require.EqualValues(t, 0, mc.rawTxCalls)
+
+	// B leaves the mempool, it is asked from the node once
+	b.confirmations.refreshMempool([]*chainhash.Hash{&hashA})
+	require.EqualValues(t, 2, b.GetConfirmations(hashB))
+	require.EqualValues(t, 1, mc.rawTxCalls)
+	b.confirmations.setTip(11)
+	require.EqualValues(t, 3, b.GetConfirmations(hashB))
+	require.EqualValues(t, 4, b.GetConfirmations(hashA))
+	require.EqualValues(t, 1, mc.rawTxCalls)
+
+	b.confirmations.setOrphaned(tracked(hashA))
+	require.EqualValues(t, 0, b.GetConfirmations(hashA))
+	require.EqualValues(t, -1, b.GetConfirmations(hashC))
+	require.EqualValues(t, 2, mc.rawTxCalls)
+}
+
+func TestBchScanner_trackConfirmationsOfScannedBlocks(t *testing.T) {
+	b, _, egtx := newAlignedTestScanner()
+	b.confirmations = newConfirmationTracker(10)
+	_, err := b.GetMainChainBlockTxs(1, 1, func(mainChainBlockHash [32]byte) [32]byte {
+		return sha256.Sum256(mainChainBlockHash[:])
+	})
+	require.NoError(t, err)
+	// the reverse of the EGTX's hash
+	txHash := common.HexToHash(egtx.Txid)
+	for i, j := 0, 31; i < j; i, j = i+1, j-1 {
+		txHash[i], txHash[j] = txHash[j], txHash[i]
+	}
+	require.EqualValues(t, 2, b.GetConfirmations(txHash))
+}
@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/doge"
+	"github.com/elfinguard/chainlogs/store"
+)
+
+var _ IScanner = &DogeScanner{}
+
+// DogeScanner scans Dogecoin's mempool and blocks, it reuses BchScanner with a Dogecoin client and output parser
+type DogeScanner struct {
+	*BchScanner
+}
+
+func NewDogeScanner(store store.IStore, params *doge.Params, mainChainClientInfos []string, maxTxsInBlock int, logger log.Logger) *DogeScanner {
+	b := BchScanner{
+		Client:        doge.NewMultiClient(mainChainClientInfos, 10, 999, logger.With("module", "client")),
+		Store:         store,
+		MaxTxsInBlock: maxTxsInBlock,
+		OutputParser:  doge.NewOutputParser(params),
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		confirmations: newConfirmationTracker(MaxCacheSize),
+		logger:        logger,
+	}
+	return &DogeScanner{BchScanner: &b}
+}
@@ -0,0 +1,138 @@
+package scanner
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/txscript"
+	"github.com/gcash/bchutil/base58"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/doge"
+)
+
+// mockDogeNode serves the JSON-RPC methods used by DogeScanner like Dogecoin Core 1.14 does
+type mockDogeNode struct {
+	blocks []*btcjson.GetBlockVerboseResult
+	txs    map[string]*bch.TxRawResult
+}
+
+func (m *mockDogeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+		Id     json.RawMessage   `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var result interface{}
+	var rpcErr *bch.JsonRpcError
+	switch req.Method {
+	case "getblockcount":
+		result = len(m.blocks)
+	case "getblockhash":
+		var height int
+		_ = json.Unmarshal(req.Params[0], &height)
+		result = m.blocks[height-1].Hash
+	case "getblock":
+		var hash string
+		var verbose bool
+		_ = json.Unmarshal(req.Params[0], &hash)
+		if len(req.Params) > 1 && json.Unmarshal(req.Params[1], &verbose) != nil {
+			rpcErr = &bch.JsonRpcError{Code: -1, Message: "JSON value is not a boolean as expected"}
+			break
+		}
+		for _, blk := range m.blocks {
+			if blk.Hash == hash {
+				result = blk
+			}
+		}
+	case "getrawtransaction":
+		var txid string
+		_ = json.Unmarshal(req.Params[0], &txid)
+		result = m.txs[txid]
+	case "getrawmempool":
+		result = []string{}
+	default:
+		rpcErr = &bch.JsonRpcError{Code: -32601, Message: "Method not found"}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"result": result, "error": rpcErr, "id": req.Id})
+}
+
+func TestDogeScanner(t *testing.T) {
+	payer := [20]byte{0x02}
+	payee := [20]byte{0x03}
+	contractAddress := [20]byte{0x01}
+	tx0 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: strings.Repeat("01", 32)}}
+	tx0.Vout = append(tx0.Vout, buildDogeVout(9_000_000_000e8, payer))
+	tx1 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: strings.Repeat("02", 32)}}
+	tx1.Vin = append(tx1.Vin, btcjson.Vin{Txid: tx0.Txid})
+	script, _ := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_RETURN).
+		AddData([]byte("EGTX")).
+		AddData(contractAddress[:]).Script()
+	tx1.Vout = append(tx1.Vout, bch.Vout{Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
+		Type: "nulldata",
+		Hex:  hex.EncodeToString(script),
+	}}})
+	// the node returns 8999999999.99999999, which is 9000000000 in float64
+	tx1.Vout = append(tx1.Vout, buildDogeVout(8_999_999_999_99999999, payee))
+	node := &mockDogeNode{
+		blocks: []*btcjson.GetBlockVerboseResult{{
+			Hash:         strings.Repeat("0a", 32),
+			Height:       1,
+			PreviousHash: strings.Repeat("00", 32),
+			Tx:           []string{tx0.Txid, tx1.Txid},
+		}},
+		txs: map[string]*bch.TxRawResult{tx0.Txid: &tx0, tx1.Txid: &tx1},
+	}
+	server := httptest.NewServer(node)
+	defer server.Close()
+
+	b := NewDogeScanner(&MockStore{}, &doge.MainNetParams, []string{strings.TrimPrefix(server.URL, "http://") + ",user,pass"}, 10, log.NewNopLogger())
+	txs, err := b.GetNewTxs(1, [32]byte{0x01}, true)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, int64(1), b.GetLatestScanHeight())
+	require.Equal(t, payer, txs[0].SrcAddr)
+	require.Equal(t, payee, txs[0].DstAddr)
+
+	var originTx types.Transaction
+	_, err = originTx.UnmarshalMsg(txs[0].Content)
+	require.NoError(t, err)
+	res, err := bch.UnPackEGTXLog(originTx.Logs[0].Data)
+	require.NoError(t, err)
+	outputs := res[1].([]*big.Int)
+	require.Len(t, outputs, 1)
+	// 899999999999999999 koinu in wei
+	output := to32Bytes(outputs[0])
+	value, _ := big.NewInt(0).SetString("8999999999999999990000000000", 10)
+	require.Equal(t, value, big.NewInt(0).SetBytes(output[20:]))
+	inputs := res[2].([]*big.Int)
+	require.Len(t, inputs, 1)
+	info, err := doge.PackAddressAndValue(payer[:], 9_000_000_000e8)
+	require.NoError(t, err)
+	require.Equal(t, info, to32Bytes(inputs[0]))
+}
+
+func buildDogeVout(value bch.Amount, addr [20]byte) bch.Vout {
+	return bch.Vout{
+		Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
+			Type:      "pubkeyhash",
+			Addresses: []string{base58.CheckEncode(addr[:], doge.MainNetParams.PubKeyHashAddrID)},
+		}},
+		Value: value,
+	}
+}
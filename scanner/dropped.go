@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+const MaxDroppedSize = 10_000
+
+const (
+	DropReasonDoubleSpent = "double-spent"
+	DropReasonEvicted     = "evicted"
+)
+
+// droppedTxs keeps the EGTXs which left the mempool without being mined, the oldest ones are dropped when it is full
+type droppedTxs struct {
+	mtx sync.RWMutex
+	txs []types.DroppedTx
+}
+
+func (d *droppedTxs) add(txid, reason string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if len(d.txs) >= MaxDroppedSize {
+		d.txs = d.txs[1:]
+	}
+	d.txs = append(d.txs, types.DroppedTx{Txid: txid, Reason: reason, Time: time.Now().Unix()})
+}
+
+func (d *droppedTxs) list() []types.DroppedTx {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return append([]types.DroppedTx{}, d.txs...)
+}
+
+// GetDroppedTxs returns the EGTXs which were double spent or evicted from the mempool
+func (b *BchScanner) GetDroppedTxs() []types.DroppedTx {
+	return b.dropped.list()
+}
+
+// txInputs returns the outputs spent by tx, except the coinbase input
+func txInputs(tx *bch.TxRawResult) []outpoint {
+	var inputs []outpoint
+	for _, vin := range tx.Vin {
+		if !vin.IsCoinBase() {
+			inputs = append(inputs, outpoint{vin.Txid, vin.Vout})
+		}
+	}
+	return inputs
+}
+
+// checkLeftMempool asks the node about the EGTXs which left the mempool. The mined ones are tracked as mined, and the
+// ones not found by the node are dropped, their logs are removed like the EGTXs orphaned by reorgs.
+func (b *BchScanner) checkLeftMempool(left map[common.Hash][]outpoint) {
+	for txHash, inputs := range left {
+		txid := txHash.Hex()[2:]
+		hash, err := chainhash.NewHashFromStr(txid)
+		if err != nil {
+			panic(err)
+		}
+		tx, err := b.Client.GetRawTransactionVerbose(hash)
+		var rpcErr *btcjson.RPCError
+		if err != nil && !errors.As(err, &rpcErr) {
+			// the node fails, check it again next time
+			b.confirmations.addPending(txHash, inputs)
+			continue
+		}
+		if err == nil {
+			if tip := b.confirmations.getTip(); tip > 0 && tx.Confirmations > 0 {
+				b.confirmations.setMined(txHash, tip-int64(tx.Confirmations)+1)
+			} else {
+				b.confirmations.addPending(txHash, inputs)
+			}
+			continue
+		}
+		reason := b.dropReason(inputs)
+		b.logger.Info("EGTX dropped from mempool", "txid", txid, "reason", reason)
+		b.dropped.add(txid, reason)
+		b.removedTxs = append(b.removedTxs, txHash)
+	}
+}
+
+// dropReason is DropReasonDoubleSpent if an input of the dropped tx is not unspent any more
+func (b *BchScanner) dropReason(inputs []outpoint) string {
+	for _, input := range inputs {
+		prevHash, err := chainhash.NewHashFromStr(input.txid)
+		if err != nil {
+			continue
+		}
+		txOut, err := b.Client.GetTxOut(prevHash, input.index, true)
+		if err == nil && txOut == nil {
+			return DropReasonDoubleSpent
+		}
+	}
+	return DropReasonEvicted
+}
@@ -0,0 +1,53 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+func TestBchScanner_checkLeftMempool(t *testing.T) {
+	mc := &bch.MockClient{}
+	hashA, hashB, hashC := chainhash.Hash{0xa}, chainhash.Hash{0xb}, chainhash.Hash{0xc}
+	prevA, prevB := chainhash.Hash{0x1a}, chainhash.Hash{0x1b}
+	mc.AddTxOut(&prevB, 0, &bch.GetTxOutResult{Value: 1e8})
+	mc.AddTx(&hashA, &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hashA.String()}})
+	mc.AddTx(&hashB, &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hashB.String()}})
+	mc.AddTx(&hashC, &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hashC.String(), Confirmations: 2}})
+	b := &BchScanner{Client: mc, confirmations: newConfirmationTracker(10), logger: log.NewNopLogger()}
+	tracked := func(h chainhash.Hash) common.Hash {
+		return common.HexToHash(h.String())
+	}
+
+	b.confirmations.setTip(5)
+	b.confirmations.addPending(tracked(hashA), []outpoint{{prevA.String(), 0}})
+	b.confirmations.addPending(tracked(hashB), []outpoint{{prevB.String(), 0}})
+	b.confirmations.addPending(tracked(hashC), nil)
+	// C is mined
+	b.checkLeftMempool(b.confirmations.refreshMempool([]*chainhash.Hash{&hashA, &hashB}))
+	require.Empty(t, b.GetDroppedTxs())
+	require.EqualValues(t, 2, b.GetConfirmations(hashC))
+
+	// A is double spent and B is evicted, they are still tracked when the node fails
+	mc.RemoveTx(&hashA)
+	mc.RemoveTx(&hashB)
+	mc.SetError(errors.New("node down"))
+	b.checkLeftMempool(b.confirmations.refreshMempool(nil))
+	require.EqualValues(t, 0, b.GetConfirmations(hashA))
+	mc.SetError(nil)
+
+	b.checkLeftMempool(b.confirmations.refreshMempool(nil))
+	dropped := b.GetDroppedTxs()
+	require.Len(t, dropped, 2)
+	reasons := map[string]string{dropped[0].Txid: dropped[0].Reason, dropped[1].Txid: dropped[1].Reason}
+	require.Equal(t, map[string]string{hashA.String(): DropReasonDoubleSpent, hashB.String(): DropReasonEvicted}, reasons)
+	require.ElementsMatch(t, [][32]byte{tracked(hashA), tracked(hashB)}, b.CollectRemovedTxs())
+	require.EqualValues(t, -1, b.GetConfirmations(hashA))
+}
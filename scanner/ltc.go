@@ -0,0 +1,32 @@
+package scanner
+
+import (
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/ltc"
+	"github.com/elfinguard/chainlogs/store"
+)
+
+var _ IScanner = &LtcScanner{}
+
+// LtcScanner scans Litecoin's mempool and blocks. Litecoin nodes serve the same JSON-RPC methods as
+// Bitcoin Cash nodes, so it reuses BchScanner and only differs in parsing outputs.
+type LtcScanner struct {
+	*BchScanner
+}
+
+func NewLtcScanner(store store.IStore, params *ltc.Params, mainChainClientInfos []string, maxTxsInBlock int, logger log.Logger) *LtcScanner {
+	b := BchScanner{
+		Client:        bch.NewMultiRetryableClient(mainChainClientInfos, 10, 999, logger.With("module", "client")),
+		Store:         store,
+		MaxTxsInBlock: maxTxsInBlock,
+		OutputParser:  ltc.NewOutputParser(params),
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		confirmations: newConfirmationTracker(MaxCacheSize),
+		logger:        logger,
+	}
+	return &LtcScanner{BchScanner: &b}
+}
@@ -0,0 +1,108 @@
+package scanner
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/txscript"
+	"github.com/gcash/bchutil/base58"
+	"github.com/gcash/bchutil/bech32"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	"golang.org/x/crypto/ripemd160"
+
+	"github.com/smartbch/moeingevm/types"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/ltc"
+)
+
+func TestLtcScanner(t *testing.T) {
+	mc := &bch.MockClient{}
+	b := LtcScanner{&BchScanner{
+		Client:        mc,
+		Store:         &MockStore{},
+		MaxTxsInBlock: 1,
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		OutputParser:  ltc.NewOutputParser(&ltc.MainNetParams),
+		logger:        log.NewNopLogger(),
+	}}
+
+	// the sender spends a P2WPKH output
+	payer := [20]byte{0x02}
+	tx0Hash := [32]byte{0x01}
+	tx0 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hex.EncodeToString(tx0Hash[:])}}
+	tx0.Vout = append(tx0.Vout, buildLtcVout(2e8, "witness_v0_keyhash", encodeLtcSegwitAddress(payer[:])))
+	tx0H, _ := chainhash.NewHash(tx0Hash[:])
+	mc.AddTx(tx0H, &tx0)
+
+	// pays to a P2WSH output and a P2SH 'M...' output
+	contractAddress := [20]byte{0x01}
+	witnessProgram := [32]byte{0x03}
+	scriptHash := [20]byte{0x04}
+	tx1Hash := [32]byte{0x02}
+	tx1 := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hex.EncodeToString(tx1Hash[:])}}
+	tx1.Vin = append(tx1.Vin, btcjson.Vin{Txid: tx0H.String()})
+	script, _ := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_RETURN).
+		AddData([]byte("EGTX")).
+		AddData(contractAddress[:]).Script()
+	tx1.Vout = append(tx1.Vout, bch.Vout{Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
+		Type: "nulldata",
+		Hex:  hex.EncodeToString(script),
+	}}})
+	tx1.Vout = append(tx1.Vout, buildLtcVout(1e8, "witness_v0_scripthash", encodeLtcSegwitAddress(witnessProgram[:])))
+	tx1.Vout = append(tx1.Vout, buildLtcVout(0.5e8, "scripthash", base58.CheckEncode(scriptHash[:], ltc.MainNetParams.ScriptHashAddrID)))
+
+	mTx, err := b.convertUtxoInfoToTx(&tx1, 0, 1, [32]byte{0x01})
+	require.NoError(t, err)
+	h := ripemd160.New()
+	h.Write(witnessProgram[:])
+	var payee [20]byte
+	copy(payee[:], h.Sum(nil))
+	require.Equal(t, payer, mTx.SrcAddr)
+	require.Equal(t, payee, mTx.DstAddr)
+
+	var originTx types.Transaction
+	_, err = originTx.UnmarshalMsg(mTx.Content)
+	require.NoError(t, err)
+	res, err := bch.UnPackEGTXLog(originTx.Logs[0].Data)
+	require.NoError(t, err)
+	outputs := res[1].([]*big.Int)
+	require.Len(t, outputs, 2)
+	require.Equal(t, bch.PackAddressAndValue(payee[:], 1e8), to32Bytes(outputs[0]))
+	require.Equal(t, bch.PackAddressAndValue(scriptHash[:], 0.5e8), to32Bytes(outputs[1]))
+	inputs := res[2].([]*big.Int)
+	require.Len(t, inputs, 1)
+	require.Equal(t, bch.PackAddressAndValue(payer[:], 2e8), to32Bytes(inputs[0]))
+
+	// the second output must pay to an address
+	tx1.Vout[1].ScriptPubKey.Type = "nonstandard"
+	_, err = b.convertUtxoInfoToTx(&tx1, 0, 1, [32]byte{0x01})
+	require.Error(t, err)
+}
+
+func buildLtcVout(value bch.Amount, scriptType, address string) bch.Vout {
+	return bch.Vout{
+		Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{
+			Type:      scriptType,
+			Addresses: []string{address},
+		}},
+		Value: value,
+	}
+}
+
+func encodeLtcSegwitAddress(program []byte) string {
+	data, _ := bech32.ConvertBits(program, 8, 5, true)
+	addr, _ := bech32.Encode(ltc.MainNetParams.Bech32HRPSegwit, append([]byte{0}, data...))
+	return addr
+}
+
+func to32Bytes(v *big.Int) (out [32]byte) {
+	v.FillBytes(out[:])
+	return
+}
@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"errors"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -19,6 +20,9 @@ type MockStore struct {
 	latestHeight  int64
 	timestamp     int64
 	latestBlkHash [32]byte
+	mainChainBlks map[int64]*store.MainChainBlock
+	checkpoint    *store.ScanCheckpoint
+	rejectedTxs   map[[32]byte]int64
 }
 
 func (m *MockStore) GetBlockByHeight(height uint64) (*types.Block, error) {
@@ -26,10 +30,28 @@ func (m *MockStore) GetBlockByHeight(height uint64) (*types.Block, error) {
 }
 
 func (m *MockStore) GetTxByHash(txHash common.Hash) (tx *types.Transaction, sig [65]byte, err error) {
+	tx = m.txByHash[txHash.Hex()]
+	if tx == nil {
+		err = errors.New("tx not found")
+	}
 	return
 }
 
 func (m *MockStore) AddBlock(blk *modbtypes.Block) {
+	if blk == nil {
+		return
+	}
+	if m.blkByHash == nil {
+		m.txByHash = make(map[string]*types.Transaction)
+		m.blkByHash = make(map[[32]byte]*modbtypes.Block)
+		m.blkByHeight = make(map[int64]*modbtypes.Block)
+	}
+	for _, mdbTx := range blk.TxList {
+		tx := &types.Transaction{}
+		if _, err := tx.UnmarshalMsg(mdbTx.Content); err == nil {
+			m.txByHash[common.Hash(tx.Hash).Hex()] = tx
+		}
+	}
 	m.blkByHash[blk.BlockHash] = blk
 	m.blkByHeight[blk.Height] = blk
 	m.latestBlkHash = blk.BlockHash
@@ -38,7 +60,7 @@ func (m *MockStore) AddBlock(blk *modbtypes.Block) {
 }
 
 func (m *MockStore) IsTxMined(txHash string) bool {
-	return m.txByHash[txHash] != nil
+	return m.txByHash[common.HexToHash(txHash).Hex()] != nil
 }
 
 func (m *MockStore) GetLatestBlockInfo() (height, timestamp int64, hash [32]byte, latestScanBlockHeight int64) {
@@ -57,6 +79,52 @@ func (m MockStore) QueryLogs(addresses []common.Address, topics [][]common.Hash,
 	return
 }
 
+func (m *MockStore) SetMainChainBlock(height int64, blk *store.MainChainBlock) {
+	if m.mainChainBlks == nil {
+		m.mainChainBlks = make(map[int64]*store.MainChainBlock)
+	}
+	m.mainChainBlks[height] = blk
+}
+
+func (m *MockStore) GetMainChainBlock(height int64) *store.MainChainBlock {
+	return m.mainChainBlks[height]
+}
+
+func (m *MockStore) DeleteMainChainBlock(height int64) {
+	delete(m.mainChainBlks, height)
+}
+
+func (m *MockStore) SetScanCheckpoint(cp *store.ScanCheckpoint) {
+	m.checkpoint = cp
+}
+
+func (m *MockStore) GetScanCheckpoint() *store.ScanCheckpoint {
+	return m.checkpoint
+}
+
+func (m *MockStore) AddRejectedTx(txid [32]byte, expireTime int64) {
+	if m.rejectedTxs == nil {
+		m.rejectedTxs = make(map[[32]byte]int64)
+	}
+	m.rejectedTxs[txid] = expireTime
+}
+
+func (m *MockStore) IsTxRejected(txid [32]byte) bool {
+	_, ok := m.rejectedTxs[txid]
+	return ok
+}
+
+func (m *MockStore) PruneRejectedTxs(now int64) int {
+	n := 0
+	for txid, expireTime := range m.rejectedTxs {
+		if expireTime < now {
+			delete(m.rejectedTxs, txid)
+			n++
+		}
+	}
+	return n
+}
+
 func (m MockStore) Close() {
 	//return
 }
@@ -0,0 +1,194 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+const (
+	DefaultPrevoutWorkers   = 8
+	DefaultPrevoutCacheSize = 100_000
+)
+
+type outpoint struct {
+	txid  string
+	index uint32
+}
+
+// PrevoutResolver fetches the outputs spent by a tx's inputs with a bounded number of workers,
+// and caches them by outpoint
+type PrevoutResolver struct {
+	client  bch.IBchClient
+	workers int
+	cache   *lru.Cache[outpoint, bch.Vout]
+
+	hits, misses, txOuts, rawTxs uint64
+	noTxOut                      uint32 // set if the node does not support gettxout
+}
+
+func NewPrevoutResolver(client bch.IBchClient, workers, cacheSize int) *PrevoutResolver {
+	if workers <= 0 {
+		workers = DefaultPrevoutWorkers
+	}
+	if cacheSize <= 0 {
+		cacheSize = DefaultPrevoutCacheSize
+	}
+	return &PrevoutResolver{
+		client:  client,
+		workers: workers,
+		cache:   lru.NewCache[outpoint, bch.Vout](cacheSize),
+	}
+}
+
+func (r *PrevoutResolver) Stats() types.PrevoutStats {
+	return types.PrevoutStats{
+		Hits:   atomic.LoadUint64(&r.hits),
+		Misses: atomic.LoadUint64(&r.misses),
+		TxOuts: atomic.LoadUint64(&r.txOuts),
+		RawTxs: atomic.LoadUint64(&r.rawTxs),
+	}
+}
+
+// prevoutJob resolves the outputs of one previous tx, indexes are the positions in tx.Vin
+type prevoutJob struct {
+	txid    string
+	indexes []int
+}
+
+// Resolve returns the outputs spent by tx.Vin in the same order, it is nil for a coinbase input.
+// The unspent outputs of a mempool tx are fetched by gettxout, otherwise the previous txs are fetched.
+func (r *PrevoutResolver) Resolve(tx *bch.TxRawResult) ([]*bch.Vout, error) {
+	prevouts := make([]*bch.Vout, len(tx.Vin))
+	var jobs []*prevoutJob
+	jobByTxid := make(map[string]*prevoutJob)
+	for i, vin := range tx.Vin {
+		if vin.IsCoinBase() {
+			continue
+		}
+		if vout, ok := r.cache.Get(outpoint{vin.Txid, vin.Vout}); ok {
+			atomic.AddUint64(&r.hits, 1)
+			prevouts[i] = &vout
+			continue
+		}
+		atomic.AddUint64(&r.misses, 1)
+		job := jobByTxid[vin.Txid]
+		if job == nil {
+			job = &prevoutJob{txid: vin.Txid}
+			jobByTxid[vin.Txid] = job
+			jobs = append(jobs, job)
+		}
+		job.indexes = append(job.indexes, i)
+	}
+	if len(jobs) == 0 {
+		return prevouts, nil
+	}
+
+	// the outputs spent by a mined tx are not in the UTXO set
+	useTxOut := tx.BlockHash == "" && atomic.LoadUint32(&r.noTxOut) == 0
+	jobCh := make(chan *prevoutJob, len(jobs))
+	for _, job := range jobs {
+		jobCh <- job
+	}
+	close(jobCh)
+	workers := r.workers
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for job := range jobCh {
+				if errs[w] = r.resolve(tx, job, useTxOut, prevouts); errs[w] != nil {
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return prevouts, nil
+}
+
+// resolve writes the distinct positions of prevouts, so the workers do not race
+func (r *PrevoutResolver) resolve(tx *bch.TxRawResult, job *prevoutJob, useTxOut bool, prevouts []*bch.Vout) error {
+	txHash, err := chainhash.NewHashFromStr(job.txid)
+	if err != nil {
+		return &types.TxError{Txid: tx.Txid, Err: err}
+	}
+	var left []int
+	for _, i := range job.indexes {
+		if !useTxOut {
+			left = append(left, i)
+			continue
+		}
+		index := tx.Vin[i].Vout
+		txOut, err := r.client.GetTxOut(txHash, index, false)
+		var rpcErr *btcjson.RPCError
+		if errors.As(err, &rpcErr) {
+			if rpcErr.Code == btcjson.ErrRPCMethodNotFound.Code {
+				atomic.StoreUint32(&r.noTxOut, 1)
+			}
+			left = append(left, i)
+			continue
+		}
+		if err != nil {
+			return &types.NodeError{Method: "gettxout", Err: err}
+		}
+		if txOut == nil {
+			// spent or unconfirmed
+			left = append(left, i)
+			continue
+		}
+		atomic.AddUint64(&r.txOuts, 1)
+		prevouts[i] = r.add(job.txid, index, bch.Vout{
+			Vout: btcjson.Vout{
+				N:            index,
+				ScriptPubKey: txOut.ScriptPubKey,
+				TokenData:    txOut.TokenData,
+			},
+			Value: txOut.Value,
+		})
+	}
+	if len(left) == 0 {
+		return nil
+	}
+	atomic.AddUint64(&r.rawTxs, 1)
+	prevTx, err := r.client.GetRawTransactionVerbose(txHash)
+	var rpcErr *btcjson.RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == btcjson.ErrRPCNoTxInfo {
+		// the node answers, but it does not know the previous tx, such as it runs without txindex
+		return &types.TxError{Txid: tx.Txid, Err: fmt.Errorf("input tx %s not found: %w", job.txid, err)}
+	}
+	if err != nil {
+		return &types.NodeError{Method: "getrawtransaction", Err: err}
+	}
+	for _, i := range left {
+		index := tx.Vin[i].Vout
+		if int(index) >= len(prevTx.Vout) {
+			return &types.TxError{Txid: tx.Txid, Err: fmt.Errorf("input %s:%d not found", job.txid, index)}
+		}
+		prevouts[i] = r.add(job.txid, index, prevTx.Vout[index])
+	}
+	return nil
+}
+
+func (r *PrevoutResolver) add(txid string, index uint32, vout bch.Vout) *bch.Vout {
+	r.cache.Add(outpoint{txid, index}, vout)
+	return &vout
+}
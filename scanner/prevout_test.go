@@ -0,0 +1,88 @@
+package scanner
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+// prevoutTestClient counts the getrawtransaction calls, which are made by several workers
+type prevoutTestClient struct {
+	*bch.MockClient
+	rawTxCalls int64
+}
+
+func (c *prevoutTestClient) GetRawTransactionVerbose(txHash *chainhash.Hash) (*bch.TxRawResult, error) {
+	atomic.AddInt64(&c.rawTxCalls, 1)
+	return c.MockClient.GetRawTransactionVerbose(txHash)
+}
+
+func TestPrevoutResolver(t *testing.T) {
+	mc := &prevoutTestClient{MockClient: &bch.MockClient{}}
+	hashA, hashB := chainhash.Hash{0xa}, chainhash.Hash{0xb}
+	mc.AddTx(&hashA, &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hashA.String()}, Vout: []bch.Vout{{Value: 1e8}, {Value: 2e8}}})
+	mc.AddTx(&hashB, &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: hashB.String()}, Vout: []bch.Vout{{Value: 3e8}}})
+	mc.AddTxOut(&hashA, 0, &bch.GetTxOutResult{Value: 1.5e8})
+
+	tx := &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{
+		Txid: "01",
+		Vin: []btcjson.Vin{
+			{Txid: hashA.String(), Vout: 0},
+			{Txid: hashA.String(), Vout: 1},
+			{Txid: hashB.String(), Vout: 0},
+		},
+	}}
+	r := NewPrevoutResolver(mc, 2, 10)
+	prevouts, err := r.Resolve(tx)
+	require.NoError(t, err)
+	require.Len(t, prevouts, 3)
+	require.EqualValues(t, 1.5e8, prevouts[0].Value) // from gettxout
+	require.EqualValues(t, 2e8, prevouts[1].Value)
+	require.EqualValues(t, 3e8, prevouts[2].Value)
+	require.Equal(t, types.PrevoutStats{Misses: 3, TxOuts: 1, RawTxs: 2}, r.Stats())
+	require.EqualValues(t, 2, mc.rawTxCalls)
+
+	// cached
+	prevouts, err = r.Resolve(tx)
+	require.NoError(t, err)
+	require.EqualValues(t, 2e8, prevouts[1].Value)
+	require.Equal(t, types.PrevoutStats{Hits: 3, Misses: 3, TxOuts: 1, RawTxs: 2}, r.Stats())
+	require.EqualValues(t, 2, mc.rawTxCalls)
+
+	// the outputs spent by a mined tx are taken from the previous txs, coinbase inputs are skipped
+	mined := &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{
+		Txid:      "02",
+		BlockHash: "03",
+		Vin:       []btcjson.Vin{{Coinbase: "04"}, {Txid: hashB.String(), Vout: 0}},
+	}}
+	r = NewPrevoutResolver(mc, 2, 10)
+	prevouts, err = r.Resolve(mined)
+	require.NoError(t, err)
+	require.Nil(t, prevouts[0])
+	require.EqualValues(t, 3e8, prevouts[1].Value)
+	require.Equal(t, types.PrevoutStats{Misses: 1, RawTxs: 1}, r.Stats())
+
+	mined.Vin[1].Vout = 1
+	_, err = r.Resolve(mined)
+	var txErr *types.TxError
+	require.ErrorAs(t, err, &txErr)
+	require.Equal(t, "02", txErr.Txid)
+
+	// the previous tx is unknown to the node
+	mined.Vin[1] = btcjson.Vin{Txid: chainhash.Hash{0xe}.String(), Vout: 0}
+	_, err = r.Resolve(mined)
+	require.ErrorAs(t, err, &txErr)
+	require.Equal(t, "02", txErr.Txid)
+
+	mc.SetError(errors.New("node down"))
+	_, err = NewPrevoutResolver(mc, 2, 10).Resolve(tx)
+	var nodeErr *types.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+}
@@ -0,0 +1,152 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/wire"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+// MaxProofHeaders limits the headers following the tx's block in a proof, as each of them takes a serial node call.
+// The newer headers up to the tip are paged with the fromHeight of GetTxProof.
+const MaxProofHeaders = 500
+
+// GetTxProof returns the SPV proof of a confirmed main chain tx, txHash is in the same byte order as GetConfirmations'.
+// The proof has count headers from fromHeight up to the tip, fromHeight defaults to the height after the tx's block
+// and count defaults to MaxProofHeaders if they are zero.
+func (b *BchScanner) GetTxProof(txHash [32]byte, fromHeight int64, count int) (*types.TxProof, error) {
+	hash, err := chainhash.NewHash(txHash[:])
+	if err != nil {
+		panic(err)
+	}
+	tx, err := b.Client.GetRawTransactionVerbose(hash)
+	if err != nil {
+		return nil, &types.NodeError{Method: "getrawtransaction", Err: err}
+	}
+	if tx.BlockHash == "" {
+		return nil, types.TxNotConfirmed
+	}
+	blockHash, err := chainhash.NewHashFromStr(tx.BlockHash)
+	if err != nil {
+		return nil, &types.NodeError{Method: "getrawtransaction", Err: err}
+	}
+	blk, err := b.Client.GetBlockVerboseTx(blockHash)
+	if err == nil && blk == nil {
+		err = fmt.Errorf("block %s not found", blockHash)
+	}
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblock", Err: err}
+	}
+	txIndex := -1
+	txids := make([]chainhash.Hash, len(blk.Tx))
+	for i, blkTx := range blk.Tx {
+		txid, err := chainhash.NewHashFromStr(blkTx.Txid)
+		if err != nil {
+			return nil, &types.NodeError{Method: "getblock", Err: err}
+		}
+		txids[i] = *txid
+		if *txid == *hash {
+			txIndex = i
+		}
+	}
+	if txIndex < 0 {
+		// the block is orphaned after the tx is fetched
+		return nil, types.TxNotConfirmed
+	}
+	branch, root := bch.MerkleBranch(txids, txIndex)
+
+	header, err := b.Client.GetBlockHeader(blockHash)
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblockheader", Err: err}
+	}
+	if header.MerkleRoot != root {
+		return nil, &types.NodeError{Method: "getblock", Err: fmt.Errorf("merkle root of block %s mismatches its txs", blockHash)}
+	}
+	proof := types.TxProof{
+		Txid:        tx.Txid,
+		RawTx:       tx.Hex,
+		BlockHash:   tx.BlockHash,
+		BlockHeight: blk.Height,
+		BlockHeader: serializeHeader(header),
+		TxIndex:     txIndex,
+	}
+	for _, sibling := range branch {
+		proof.MerkleBranch = append(proof.MerkleBranch, sibling.String())
+	}
+
+	newestHeight, err := b.Client.GetBlockCount()
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblockcount", Err: err}
+	}
+	proof.TipHeight = newestHeight
+	if fromHeight == 0 {
+		fromHeight = blk.Height + 1
+	}
+	if count == 0 {
+		count = MaxProofHeaders
+	}
+	if fromHeight <= blk.Height || count < 0 || count > MaxProofHeaders {
+		return nil, fmt.Errorf("invalid headers from height %d with count %d, they must follow block %d and at most %d are returned",
+			fromHeight, count, blk.Height, MaxProofHeaders)
+	}
+	toHeight := fromHeight + int64(count) - 1
+	if toHeight > newestHeight {
+		toHeight = newestHeight
+	}
+	if toHeight < fromHeight {
+		return &proof, nil
+	}
+	prevHash := blockHash
+	if fromHeight > blk.Height+1 {
+		prevHash, err = b.Client.GetBlockHash(fromHeight - 1)
+		if err == nil && prevHash == nil {
+			err = fmt.Errorf("block at height %d not found", fromHeight-1)
+		}
+		if err != nil {
+			return nil, &types.NodeError{Method: "getblockhash", Err: err}
+		}
+	}
+	proof.Headers, err = b.getHeaders(fromHeight, toHeight, *prevHash)
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// getHeaders returns the serialized headers from fromHeight to toHeight. They are fetched backward from toHeight
+// by their parent hashes, which takes one node call per header, and the first one must follow prevHash.
+func (b *BchScanner) getHeaders(fromHeight, toHeight int64, prevHash chainhash.Hash) ([]string, error) {
+	hash, err := b.Client.GetBlockHash(toHeight)
+	if err == nil && hash == nil {
+		err = fmt.Errorf("block at height %d not found", toHeight)
+	}
+	if err != nil {
+		return nil, &types.NodeError{Method: "getblockhash", Err: err}
+	}
+	headers := make([]string, toHeight-fromHeight+1)
+	for i := len(headers) - 1; i >= 0; i-- {
+		header, err := b.Client.GetBlockHeader(hash)
+		if err != nil {
+			return nil, &types.NodeError{Method: "getblockheader", Err: err}
+		}
+		headers[i] = serializeHeader(header)
+		hash = &header.PrevBlock
+	}
+	if *hash != prevHash {
+		return nil, &types.NodeError{Method: "getblockheader", Err: fmt.Errorf("main chain reorg between height %d and %d", fromHeight-1, toHeight)}
+	}
+	return headers, nil
+}
+
+func serializeHeader(header *wire.BlockHeader) string {
+	var buf bytes.Buffer
+	if err := header.Serialize(&buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf.Bytes())
+}
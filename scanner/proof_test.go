@@ -0,0 +1,78 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/wire"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+func TestBchScanner_GetTxProof(t *testing.T) {
+	mc := &bch.MockClient{}
+	blk1 := buildMainChainBlock(1, 0x11, 0x00)
+	var txids []chainhash.Hash
+	for _, b := range []byte{0xa, 0xb, 0xc} {
+		txid := chainhash.Hash{b}
+		tx := bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: txid.String(), Hex: "0100", BlockHash: blk1.Hash}}
+		mc.AddTx(&txid, &tx)
+		blk1.Tx = append(blk1.Tx, tx)
+		txids = append(txids, txid)
+	}
+	branch, root := bch.MerkleBranch(txids, 1)
+	blk1.MerkleRoot = root.String()
+	mempoolTxid := chainhash.Hash{0xd}
+	mc.AddTx(&mempoolTxid, &bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: mempoolTxid.String()}})
+	for _, blk := range []*bch.GetBlockVerboseTxResult{blk1, buildMainChainBlock(2, 0x12, 0x11), buildMainChainBlock(3, 0x13, 0x12)} {
+		blk.Bits = "1d00ffff"
+		mc.AddBlock(blk)
+	}
+	b := &BchScanner{Client: mc}
+
+	proof, err := b.GetTxProof(txids[1], 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, txids[1].String(), proof.Txid)
+	require.Equal(t, "0100", proof.RawTx)
+	require.Equal(t, blk1.Hash, proof.BlockHash)
+	require.EqualValues(t, 1, proof.BlockHeight)
+	require.Equal(t, 1, proof.TxIndex)
+	require.Equal(t, []string{branch[0].String(), branch[1].String()}, proof.MerkleBranch)
+	var header wire.BlockHeader
+	bz, _ := hex.DecodeString(proof.BlockHeader)
+	require.NoError(t, header.Deserialize(bytes.NewReader(bz)))
+	require.Equal(t, root, header.MerkleRoot)
+	require.Len(t, proof.Headers, 2)
+	bz, _ = hex.DecodeString(proof.Headers[0])
+	require.NoError(t, header.Deserialize(bytes.NewReader(bz)))
+	require.Equal(t, chainhash.Hash{0x11}, header.PrevBlock)
+	require.EqualValues(t, 3, proof.TipHeight)
+
+	// the headers are paged
+	proof, err = b.GetTxProof(txids[1], 3, 1)
+	require.NoError(t, err)
+	require.Len(t, proof.Headers, 1)
+	bz, _ = hex.DecodeString(proof.Headers[0])
+	require.NoError(t, header.Deserialize(bytes.NewReader(bz)))
+	require.Equal(t, chainhash.Hash{0x12}, header.PrevBlock)
+	proof, err = b.GetTxProof(txids[1], 4, 1)
+	require.NoError(t, err)
+	require.Empty(t, proof.Headers)
+	_, err = b.GetTxProof(txids[1], 1, 1)
+	require.Error(t, err)
+	_, err = b.GetTxProof(txids[1], 0, MaxProofHeaders+1)
+	require.Error(t, err)
+
+	_, err = b.GetTxProof(mempoolTxid, 0, 0)
+	require.ErrorIs(t, err, types.TxNotConfirmed)
+
+	blk1.MerkleRoot = chainhash.Hash{0x01}.String()
+	_, err = b.GetTxProof(txids[1], 0, 0)
+	var nodeErr *types.NodeError
+	require.ErrorAs(t, err, &nodeErr)
+}
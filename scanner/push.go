@@ -0,0 +1,129 @@
+package scanner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gcash/bchd/chaincfg/chainhash"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/zmq"
+)
+
+const zmqRetryDelay = 10 * time.Second
+
+// pushQueue collects the EGTXs and blocks pushed by the main chain node through ZMQ
+type pushQueue struct {
+	mtx       sync.Mutex
+	connected bool
+	missed    bool // some notifications may be lost, so the mempool should be polled once
+	txs       []*chainhash.Hash
+
+	notify chan bool // true means a new block
+}
+
+// takeTxs returns the pushed EGTXs, or false if the mempool should be polled instead
+func (q *pushQueue) takeTxs() ([]*chainhash.Hash, bool) {
+	if q == nil {
+		return nil, false
+	}
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	txs := q.txs
+	q.txs = nil
+	if !q.connected || q.missed {
+		q.missed = false
+		return nil, false
+	}
+	return txs, true
+}
+
+func (q *pushQueue) addTx(txHash *chainhash.Hash) {
+	q.mtx.Lock()
+	q.txs = append(q.txs, txHash)
+	q.mtx.Unlock()
+	q.signal(false)
+}
+
+func (q *pushQueue) setConnected(connected bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.connected = connected
+	q.missed = true
+}
+
+func (q *pushQueue) setMissed() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.missed = true
+}
+
+// signal coalesces the notifications which are not handled yet
+func (q *pushQueue) signal(newBlock bool) {
+	select {
+	case old := <-q.notify:
+		newBlock = newBlock || old
+	default:
+	}
+	select {
+	case q.notify <- newBlock:
+	default:
+	}
+}
+
+// ListenZmq subscribes to the node's zmqpubrawtx and zmqpubhashblock notifications at addr, such as
+// tcp://127.0.0.1:28332. While subscribed, new EGTXs are taken from the notifications instead of polling
+// the whole mempool. The returned channel is signaled when EGTXs or blocks arrive, true means a new block.
+func (b *BchScanner) ListenZmq(addr string) <-chan bool {
+	b.push = &pushQueue{notify: make(chan bool, 1)}
+	go b.zmqLoop(addr)
+	return b.push.notify
+}
+
+func (b *BchScanner) zmqLoop(addr string) {
+	for {
+		sub, err := zmq.Subscribe(addr, zmqRetryDelay, "rawtx", "hashblock")
+		if err != nil {
+			b.logger.Error("subscribe zmq failed, fall back to polling", "addr", addr, "error", err)
+			time.Sleep(zmqRetryDelay)
+			continue
+		}
+		b.logger.Info("zmq subscribed", "addr", addr)
+		b.push.setConnected(true)
+		err = b.receiveZmq(sub)
+		b.push.setConnected(false)
+		_ = sub.Close()
+		b.logger.Error("zmq disconnected, fall back to polling", "addr", addr, "error", err)
+	}
+}
+
+func (b *BchScanner) receiveZmq(sub *zmq.Subscriber) error {
+	seqs := make(map[string]uint32)
+	for {
+		msg, err := sub.Receive()
+		if err != nil {
+			return err
+		}
+		if last, ok := seqs[msg.Topic]; ok && msg.Seq != last+1 {
+			b.logger.Info("zmq notifications lost", "topic", msg.Topic, "lastSeq", last, "seq", msg.Seq)
+			b.push.setMissed()
+		}
+		seqs[msg.Topic] = msg.Seq
+		switch msg.Topic {
+		case "rawtx":
+			txid, isEGTX, err := bch.ParseRawTx(msg.Body)
+			if err != nil {
+				// let polling find it
+				b.logger.Debug("parse zmq raw tx failed", "error", err)
+				b.push.setMissed()
+				b.push.signal(false)
+				continue
+			}
+			if isEGTX {
+				b.push.addTx(&txid)
+			}
+		case "hashblock":
+			b.push.signal(true)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/chaincfg"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/gcash/bchd/wire"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/zmq"
+)
+
+// pushTestClient counts the mempool polls, and its mempool is always empty
+type pushTestClient struct {
+	*bch.MockClient
+	mempoolPolls int
+}
+
+func (c *pushTestClient) GetRawMempool() ([]*chainhash.Hash, error) {
+	c.mempoolPolls++
+	return nil, nil
+}
+
+func TestBchScanner_zmq(t *testing.T) {
+	p, err := zmq.NewPublisher("127.0.0.1:0")
+	require.NoError(t, err)
+	defer p.Close()
+
+	mc := &pushTestClient{MockClient: &bch.MockClient{}}
+	b := BchScanner{
+		Client:        mc,
+		Store:         &MockStore{},
+		MaxTxsInBlock: 100,
+		OutputParser:  bch.NewOutputParser(&chaincfg.MainNetParams),
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		reorgedTxs:    make(map[[32]byte]struct{}),
+		logger:        log.NewNopLogger(),
+	}
+	notify := b.ListenZmq(p.Addr())
+	require.Eventually(t, func() bool { return p.Subscribed("rawtx") && p.Subscribed("hashblock") }, time.Second, 10*time.Millisecond)
+
+	// the mempool is polled once after subscribing
+	txs, err := b.GetNewTxs(1, [32]byte{1}, false)
+	require.NoError(t, err)
+	require.Len(t, txs, 0)
+	require.Equal(t, 1, mc.mempoolPolls)
+
+	// an EGTX is pushed
+	egtx, _, _, _, _, _ := buildEGTx(mc.MockClient)
+	msgTx := wire.NewMsgTx(2)
+	msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil))
+	for _, vout := range egtx.Vout {
+		script, _ := hex.DecodeString(vout.ScriptPubKey.Hex)
+		msgTx.AddTxOut(wire.NewTxOut(int64(vout.Value), script))
+	}
+	txHash := msgTx.TxHash()
+	egtx.Txid = txHash.String()
+	mc.AddTx(&txHash, egtx)
+	var raw bytes.Buffer
+	require.NoError(t, msgTx.Serialize(&raw))
+	p.Publish("rawtx", raw.Bytes(), 0)
+	require.False(t, <-notify)
+	txs, err = b.GetNewTxs(2, [32]byte{2}, false)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, common.HexToHash(txHash.String()), common.Hash(txs[0].HashId))
+	require.Equal(t, 1, mc.mempoolPolls)
+
+	// non-EGTXs are ignored, a new block is signaled
+	msgTx.TxOut = msgTx.TxOut[1:]
+	raw.Reset()
+	require.NoError(t, msgTx.Serialize(&raw))
+	p.Publish("rawtx", raw.Bytes(), 1)
+	p.Publish("hashblock", make([]byte, 32), 0)
+	require.True(t, <-notify)
+	txs, err = b.GetNewTxs(3, [32]byte{3}, false)
+	require.NoError(t, err)
+	require.Len(t, txs, 0)
+	require.Equal(t, 1, mc.mempoolPolls)
+
+	// the mempool is polled when notifications are lost
+	p.Publish("rawtx", raw.Bytes(), 5)
+	require.Eventually(t, func() bool {
+		_, _ = b.GetNewTxs(4, [32]byte{4}, false)
+		return mc.mempoolPolls == 2
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,47 @@
+package scanner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elfinguard/chainlogs/types"
+)
+
+const MaxQuarantineSize = 10_000
+
+// quarantine keeps the EGTXs which can not be derived, the oldest ones are dropped when it is full
+type quarantine struct {
+	mtx   sync.RWMutex
+	txs   []types.QuarantinedTx
+	txids map[string]struct{}
+}
+
+func (q *quarantine) add(txid string, err error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.txids == nil {
+		q.txids = make(map[string]struct{})
+	}
+	if _, ok := q.txids[txid]; ok {
+		return
+	}
+	if len(q.txs) >= MaxQuarantineSize {
+		delete(q.txids, q.txs[0].Txid)
+		q.txs = q.txs[1:]
+	}
+	q.txs = append(q.txs, types.QuarantinedTx{Txid: txid, Reason: err.Error(), Time: time.Now().Unix()})
+	q.txids[txid] = struct{}{}
+}
+
+func (q *quarantine) has(txid string) bool {
+	q.mtx.RLock()
+	defer q.mtx.RUnlock()
+	_, ok := q.txids[txid]
+	return ok
+}
+
+func (q *quarantine) list() []types.QuarantinedTx {
+	q.mtx.RLock()
+	defer q.mtx.RUnlock()
+	return append([]types.QuarantinedTx{}, q.txs...)
+}
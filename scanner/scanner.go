@@ -1,12 +1,35 @@
 package scanner
 
 import (
+	"github.com/gcash/bchd/btcjson"
 	modbtypes "github.com/smartbch/moeingdb/types"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
 )
 
 type IScanner interface {
-	GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock bool) []modbtypes.Tx
+	GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock bool) ([]modbtypes.Tx, error)
+	GetBlockTxs(blockHeight int64, blockHash [32]byte, toHeight int64) ([]modbtypes.Tx, error)
 	GetConfirmations(txHash [32]byte) int32
+	CollectRemovedTxs() [][32]byte
 	SetLatestScanHeight(blockHeight int64)
 	GetLatestScanHeight() int64
+	MainChainEndpoints() []bch.EndpointStatus
+	GetQuarantinedTxs() []types.QuarantinedTx
+	GetDroppedTxs() []types.DroppedTx
+	GetSkippedBlocks() []types.SkippedBlock
+	PrevoutStats() types.PrevoutStats
+	GetTxProof(txHash [32]byte, fromHeight int64, count int) (*types.TxProof, error)
+	GetSourceTx(txHash [32]byte) (*types.SourceTx, error)
+	GetScanStatus() (types.ScanStatus, error)
+	GetMainChainBlockTxs(blockHeight, minConfirmations int64, deriveHash func(mainChainBlockHash [32]byte) [32]byte) (*MainChainBlockTxs, error)
+}
+
+// IOutputParser extracts the address info from outputs, it differs among the UTXO chains
+type IOutputParser interface {
+	// IsAddressOutput returns whether the output pays to an address whose info is collected in EGTX logs
+	IsAddressOutput(pkScript *btcjson.ScriptPubKeyResult) bool
+	// ExtractOutputInfo returns the output's 20-byte address and 12-byte value packed in one word
+	ExtractOutputInfo(vout *bch.Vout) ([32]byte, error)
 }
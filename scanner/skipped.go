@@ -0,0 +1,49 @@
+package scanner
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gcash/bchd/chaincfg/chainhash"
+
+	"github.com/elfinguard/chainlogs/store"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+const MaxSkippedSize = 10_000
+
+// skippedBlocks keeps the main chain blocks skipped without quorum, the oldest ones are dropped when it is full
+type skippedBlocks struct {
+	mtx    sync.RWMutex
+	blocks []types.SkippedBlock
+}
+
+func (s *skippedBlocks) add(blk types.SkippedBlock) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.blocks) >= MaxSkippedSize {
+		s.blocks = s.blocks[1:]
+	}
+	s.blocks = append(s.blocks, blk)
+}
+
+func (s *skippedBlocks) list() []types.SkippedBlock {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return append([]types.SkippedBlock{}, s.blocks...)
+}
+
+// GetSkippedBlocks returns the main chain blocks skipped because the nodes do not reach quorum on them
+func (b *BchScanner) GetSkippedBlocks() []types.SkippedBlock {
+	return b.skipped.list()
+}
+
+// skipBlock moves on from a main chain block whose hash is agreed but whose content the nodes disagree on.
+// The quorum client halts the process instead if -haltOnDisagreement is set.
+func (b *BchScanner) skipBlock(height int64, hash chainhash.Hash, err error) {
+	b.logger.Error("skip main chain block", "height", height, "hash", hash, "error", err)
+	b.skipped.add(types.SkippedBlock{Height: height, Hash: hash.String(), Reason: err.Error(), Time: time.Now().Unix()})
+	// record its hash, so the reorgs are still detected
+	b.Store.SetMainChainBlock(height, &store.MainChainBlock{Hash: hash})
+	b.SetLatestScanHeight(height)
+}
@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+)
+
+func TestBchScanner_skipBlockWithoutQuorum(t *testing.T) {
+	var nodes []*bch.MockClient
+	var endpoints []bch.Endpoint
+	for i := 0; i < 3; i++ {
+		n := &bch.MockClient{}
+		n.AddBlock(buildMainChainBlock(1, 0x11, 0x00))
+		// the nodes agree on the hash of block 2, but not on its txs
+		n.AddBlock(buildMainChainBlock(2, 0x12, 0x11, bch.TxRawResult{TxRawResult: btcjson.TxRawResult{Txid: chainhash.Hash{byte(i)}.String()}}))
+		n.AddBlock(buildMainChainBlock(3, 0x13, 0x12))
+		nodes = append(nodes, n)
+		endpoints = append(endpoints, bch.Endpoint{Url: string(rune('a' + i)), Client: n})
+	}
+	store := &MockStore{}
+	b := &BchScanner{
+		Client:        bch.NewQuorumClient(nodes[0], endpoints, 2, false, 0, 1, log.NewNopLogger()),
+		Store:         store,
+		MaxTxsInBlock: 10,
+		knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+		logger:        log.NewNopLogger(),
+	}
+	var txIndex int64
+	_, err := b.collectMainChainBlockTxs(1, [32]byte{}, &txIndex, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, b.GetLatestScanHeight())
+	skipped := b.GetSkippedBlocks()
+	require.Len(t, skipped, 1)
+	require.EqualValues(t, 2, skipped[0].Height)
+	require.Equal(t, chainhash.Hash{0x12}.String(), skipped[0].Hash)
+	require.EqualValues(t, chainhash.Hash{0x12}, store.GetMainChainBlock(2).Hash)
+
+	// the nodes are down, it is retried instead of skipped
+	for _, n := range nodes {
+		n.AddBlock(buildMainChainBlock(4, 0x14, 0x13))
+		n.SetError(errors.New("connection refused"))
+	}
+	nodes[0].SetError(nil)
+	_, err = b.collectMainChainBlockTxs(1, [32]byte{}, &txIndex, 0)
+	require.Error(t, err)
+	require.EqualValues(t, 3, b.GetLatestScanHeight())
+	require.Len(t, b.GetSkippedBlocks(), 1)
+}
@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+// GetSourceTx returns a main chain tx with its inputs' prevouts, txHash is in the same byte order as GetConfirmations'
+func (b *BchScanner) GetSourceTx(txHash [32]byte) (*types.SourceTx, error) {
+	hash, err := chainhash.NewHash(txHash[:])
+	if err != nil {
+		panic(err)
+	}
+	tx, err := b.Client.GetRawTransactionVerbose(hash)
+	if err != nil {
+		return nil, &types.NodeError{Method: "getrawtransaction", Err: err}
+	}
+	prevoutResolver := b.Prevouts
+	if prevoutResolver == nil {
+		prevoutResolver = NewPrevoutResolver(b.Client, DefaultPrevoutWorkers, DefaultPrevoutCacheSize)
+	}
+	prevouts, err := prevoutResolver.Resolve(tx)
+	if err != nil {
+		return nil, err
+	}
+	sourceTx := &types.SourceTx{
+		Txid:          tx.Txid,
+		Hex:           tx.Hex,
+		BlockHash:     tx.BlockHash,
+		Confirmations: tx.Confirmations,
+		Inputs:        make([]types.SourceInput, len(tx.Vin)),
+		Outputs:       make([]types.SourceOutput, len(tx.Vout)),
+	}
+	for i, vin := range tx.Vin {
+		sourceTx.Inputs[i] = types.SourceInput{Txid: vin.Txid, Vout: vin.Vout}
+		if prevouts[i] != nil {
+			prevout := b.toSourceOutput(prevouts[i])
+			sourceTx.Inputs[i].Prevout = &prevout
+		}
+	}
+	for i := range tx.Vout {
+		sourceTx.Outputs[i] = b.toSourceOutput(&tx.Vout[i])
+	}
+	return sourceTx, nil
+}
+
+// toSourceOutput leaves the address empty if it can not be extracted, the tx is quarantined if it is an EGTX
+func (b *BchScanner) toSourceOutput(vout *bch.Vout) types.SourceOutput {
+	out := types.SourceOutput{
+		Value:      int64(vout.Value),
+		ScriptType: vout.ScriptPubKey.Type,
+		Script:     vout.ScriptPubKey.Hex,
+	}
+	if b.OutputParser.IsAddressOutput(&vout.ScriptPubKey) {
+		if info, err := b.OutputParser.ExtractOutputInfo(vout); err == nil {
+			address := common.BytesToAddress(info[:20])
+			out.Address = &address
+		}
+	}
+	if vout.TokenData.Category != "" {
+		tokenData := vout.TokenData
+		out.TokenData = &tokenData
+	}
+	return out
+}
+
+// GetScanStatus asks the node for its tip, the lag is the number of main chain blocks not scanned yet
+func (b *BchScanner) GetScanStatus() (types.ScanStatus, error) {
+	tip, err := b.Client.GetBlockCount()
+	if err != nil {
+		return types.ScanStatus{}, &types.NodeError{Method: "getblockcount", Err: err}
+	}
+	scanHeight := b.GetLatestScanHeight()
+	status := types.ScanStatus{ScanHeight: scanHeight, NodeTip: tip}
+	if tip > scanHeight {
+		status.Lag = tip - scanHeight
+	}
+	return status, nil
+}
@@ -0,0 +1,52 @@
+package scanner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/types"
+)
+
+func TestBchScanner_GetSourceTx(t *testing.T) {
+	mc := &bch.MockClient{}
+	tx, _, payer, payee, _, _ := buildEGTx(mc)
+	tx.Vout[1].TokenData = btcjson.TokenDataResult{Category: "0f3dd8905e42c5911245da88ca3600bb4f39d9cbcad7672512e59d059b2428b6", Amount: "10"}
+	b := &BchScanner{Client: mc, OutputParser: bch.NewOutputParser(&chaincfg.MainNetParams)}
+
+	sourceTx, err := b.GetSourceTx([32]byte{0x02})
+	require.NoError(t, err)
+	require.Equal(t, tx.Txid, sourceTx.Txid)
+	require.Len(t, sourceTx.Inputs, 1)
+	require.Equal(t, common.Address(payer), *sourceTx.Inputs[0].Prevout.Address)
+	require.EqualValues(t, 1e8, sourceTx.Inputs[0].Prevout.Value)
+	require.Len(t, sourceTx.Outputs, 2)
+	require.Equal(t, "nulldata", sourceTx.Outputs[0].ScriptType)
+	require.Nil(t, sourceTx.Outputs[0].Address)
+	require.Nil(t, sourceTx.Outputs[0].TokenData)
+	require.Equal(t, common.Address(payee), *sourceTx.Outputs[1].Address)
+	require.Equal(t, "10", sourceTx.Outputs[1].TokenData.Amount)
+
+	_, err = b.GetSourceTx([32]byte{0x03})
+	require.IsType(t, &types.NodeError{}, err)
+}
+
+func TestBchScanner_GetScanStatus(t *testing.T) {
+	mc := &bch.MockClient{}
+	for h := int64(1); h <= 5; h++ {
+		mc.AddBlock(buildMainChainBlock(h, byte(0x10+h), byte(0x10+h-1)))
+	}
+	b := &BchScanner{Client: mc, LatestScanBlockHeight: 3}
+	status, err := b.GetScanStatus()
+	require.NoError(t, err)
+	require.Equal(t, types.ScanStatus{ScanHeight: 3, NodeTip: 5, Lag: 2}, status)
+
+	mc.SetError(errors.New("node down"))
+	_, err = b.GetScanStatus()
+	require.IsType(t, &types.NodeError{}, err)
+}
@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/gcash/bchd/btcjson"
+	"github.com/gcash/bchd/chaincfg"
+	"github.com/gcash/bchd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/elfinguard/chainlogs/bch"
+	"github.com/elfinguard/chainlogs/store"
+)
+
+func TestBchScanner_scanCheckpoint(t *testing.T) {
+	b, _, _ := newAlignedTestScanner()
+	b.knownTxCache = lru.NewCache[string, bool](MaxCacheSize)
+	var txIndex int64
+	// block 1 has an EGTX which is not stored yet
+	txs, err := b.collectMainChainBlockTxs(1, [32]byte{0x01}, &txIndex, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Nil(t, b.Store.GetScanCheckpoint())
+
+	txs, err = b.collectMainChainBlockTxs(2, [32]byte{0x02}, &txIndex, 0)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+	require.Equal(t, &store.ScanCheckpoint{Height: 2, Hash: chainhash.Hash{0x12}}, b.Store.GetScanCheckpoint())
+}
+
+func TestBchScanner_rejectedTxs(t *testing.T) {
+	mc := &prevoutTestClient{MockClient: &bch.MockClient{}}
+	hashA := chainhash.Hash{0xa}
+	// A is a plain payment
+	mc.AddTx(&hashA, &bch.TxRawResult{
+		TxRawResult: btcjson.TxRawResult{Txid: hashA.String()},
+		Vout:        []bch.Vout{{Vout: btcjson.Vout{ScriptPubKey: btcjson.ScriptPubKeyResult{Type: "pubkeyhash"}}}},
+	})
+	st := &MockStore{}
+	newScanner := func() *BchScanner {
+		return &BchScanner{
+			Client:        mc,
+			Store:         st,
+			MaxTxsInBlock: 10,
+			knownTxCache:  lru.NewCache[string, bool](MaxCacheSize),
+			OutputParser:  bch.NewOutputParser(&chaincfg.MainNetParams),
+			logger:        log.NewNopLogger(),
+		}
+	}
+	txs, err := newScanner().GetNewTxs(1, [32]byte{0x01}, false)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+	require.EqualValues(t, 1, mc.rawTxCalls)
+
+	// A is not fetched again after a restart
+	_, err = newScanner().GetNewTxs(1, [32]byte{0x01}, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, mc.rawTxCalls)
+
+	require.Equal(t, 1, st.PruneRejectedTxs(time.Now().Add(RejectedTxTTL+time.Minute).Unix()))
+	_, err = newScanner().GetNewTxs(1, [32]byte{0x01}, false)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, mc.rawTxCalls)
+}
+
+func TestBchScanner_GetBlockTxs(t *testing.T) {
+	b, _, egtx := newAlignedTestScanner()
+	b.knownTxCache = lru.NewCache[string, bool](MaxCacheSize)
+	_, err := b.GetBlockTxs(1, [32]byte{0x01}, 3)
+	require.Error(t, err)
+
+	txs, err := b.GetBlockTxs(1, [32]byte{0x01}, 1)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, common.HexToHash(egtx.Txid), common.Hash(txs[0].HashId))
+	require.EqualValues(t, 1, b.GetLatestScanHeight())
+
+	txs, err = b.GetBlockTxs(1, [32]byte{0x01}, 2)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+	require.EqualValues(t, 2, b.GetLatestScanHeight())
+}
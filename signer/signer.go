@@ -0,0 +1,92 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrNoSigner = errors.New("no operator key is configured")
+
+// Signer signs the log digests with the operator key, a remote signer can implement it without exposing the key
+type Signer interface {
+	Address() common.Address
+	// Sign returns a 65-byte [R || S || V] signature, V is 27 or 28 as ecrecover expects
+	Sign(digest common.Hash) ([]byte, error)
+}
+
+var _ Signer = &KeyFileSigner{}
+
+// KeyFileSigner signs with a private key loaded from a file
+type KeyFileSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewKeyFileSigner loads the hex encoded private key in file
+func NewKeyFileSigner(file string) (*KeyFileSigner, error) {
+	key, err := crypto.LoadECDSA(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key), nil
+}
+
+func NewKeySigner(key *ecdsa.PrivateKey) *KeyFileSigner {
+	return &KeyFileSigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+func (s *KeyFileSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *KeyFileSigner) Sign(digest common.Hash) ([]byte, error) {
+	sig, err := crypto.Sign(digest[:], s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// LogDigest is the digest signed for a log, which is the same as this solidity expression:
+// keccak256(abi.encodePacked(chainId, txid, contract, confirmations, keccak256(data), topics))
+// where chainId and confirmations are uint256, txid is bytes32 and topics is bytes32[]
+func LogDigest(chainId *big.Int, txid common.Hash, contract common.Address, topics []common.Hash, data []byte, confirmations uint64) common.Hash {
+	var confirmationsBz [32]byte
+	binary.BigEndian.PutUint64(confirmationsBz[24:], confirmations)
+	bzs := [][]byte{
+		common.BigToHash(chainId).Bytes(),
+		txid.Bytes(),
+		contract.Bytes(),
+		confirmationsBz[:],
+		crypto.Keccak256(data),
+	}
+	for _, topic := range topics {
+		bzs = append(bzs, topic.Bytes())
+	}
+	return crypto.Keccak256Hash(bzs...)
+}
+
+// Recover returns the address which signed digest, it accepts V as 27/28 or 0/1
+func Recover(digest common.Hash, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, errors.New("invalid signature length")
+	}
+	sig = common.CopyBytes(sig)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pubKey, err := crypto.SigToPub(digest[:], sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
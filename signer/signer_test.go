@@ -0,0 +1,55 @@
+package signer
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyFileSigner(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "operator.key")
+	require.NoError(t, os.WriteFile(file, []byte("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"), 0600))
+	s, err := NewKeyFileSigner(file)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"), s.Address())
+
+	_, err = NewKeyFileSigner(filepath.Join(t.TempDir(), "missing.key"))
+	require.Error(t, err)
+
+	topics := []common.Hash{{0x01}, {0x02}}
+	digest := LogDigest(big.NewInt(10000), common.Hash{0xaa}, common.Address{0xbb}, topics, []byte{0xcc}, 6)
+	sig, err := s.Sign(digest)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	require.Contains(t, []byte{27, 28}, sig[64])
+	signer, err := Recover(digest, sig)
+	require.NoError(t, err)
+	require.Equal(t, s.Address(), signer)
+
+	// every field is covered
+	for _, other := range []common.Hash{
+		LogDigest(big.NewInt(10001), common.Hash{0xaa}, common.Address{0xbb}, topics, []byte{0xcc}, 6),
+		LogDigest(big.NewInt(10000), common.Hash{0xab}, common.Address{0xbb}, topics, []byte{0xcc}, 6),
+		LogDigest(big.NewInt(10000), common.Hash{0xaa}, common.Address{0xbc}, topics, []byte{0xcc}, 6),
+		LogDigest(big.NewInt(10000), common.Hash{0xaa}, common.Address{0xbb}, topics[:1], []byte{0xcc}, 6),
+		LogDigest(big.NewInt(10000), common.Hash{0xaa}, common.Address{0xbb}, topics, []byte{0xcd}, 6),
+		LogDigest(big.NewInt(10000), common.Hash{0xaa}, common.Address{0xbb}, topics, []byte{0xcc}, 7),
+	} {
+		require.NotEqual(t, digest, other)
+	}
+}
+
+func TestLogDigest(t *testing.T) {
+	// keccak256(abi.encodePacked(uint256(1), bytes32(0), address(0), uint256(2), keccak256(""), bytes32[]))
+	packed := make([]byte, 0, 148)
+	packed = append(packed, common.BigToHash(big.NewInt(1)).Bytes()...)
+	packed = append(packed, make([]byte, 52)...)
+	packed = append(packed, common.BigToHash(big.NewInt(2)).Bytes()...)
+	packed = append(packed, crypto.Keccak256(nil)...)
+	require.Equal(t, crypto.Keccak256Hash(packed), LogDigest(big.NewInt(1), common.Hash{}, common.Address{}, nil, nil, 2))
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartbch/moeingads/indextree"
 	"github.com/smartbch/moeingdb/modb"
 	"github.com/smartbch/moeingdb/types"
 	evmtypes "github.com/smartbch/moeingevm/types"
@@ -25,11 +26,32 @@ type IStore interface {
 	GetTxByHash(txHash common.Hash) (tx *evmtypes.Transaction, sig [65]byte, err error)
 	GetTxListByHeightWithRange(height uint32, start, end int) (txs []*evmtypes.Transaction, sigs [][65]byte, err error)
 	QueryLogs(addresses []common.Address, topics [][]common.Hash, startHeight, endHeight uint32, filter evmtypes.FilterFunc) (logs []evmtypes.Log, err error)
+	SetMainChainBlock(height int64, blk *MainChainBlock)
+	GetMainChainBlock(height int64) *MainChainBlock
+	DeleteMainChainBlock(height int64)
+	SetScanCheckpoint(cp *ScanCheckpoint)
+	GetScanCheckpoint() *ScanCheckpoint
+	AddRejectedTx(txid [32]byte, expireTime int64)
+	IsTxRejected(txid [32]byte) bool
+	PruneRejectedTxs(now int64) int
 	Close()
 }
 
+// ScanCheckpoint is the latest main chain block scanned by the adaptor, it is saved even if no virtual block is built
+type ScanCheckpoint struct {
+	Height int64
+	Hash   [32]byte
+}
+
+// MainChainBlock records a main chain block scanned by the adaptor, it is used to detect reorgs
+type MainChainBlock struct {
+	Hash  [32]byte
+	Txids [][32]byte // EGTXs mined in this block
+}
+
 type ChainLogDB struct {
-	modb *modb.MoDB
+	modb   *modb.MoDB
+	scanDB *indextree.RocksDB // main chain blocks scanned by the adaptor
 }
 
 func NewChainLogDB(dataPath string, maxLogResults int, logger log.Logger) *ChainLogDB {
@@ -43,6 +65,11 @@ func NewChainLogDB(dataPath string, maxLogResults int, logger log.Logger) *Chain
 		a.modb = modb.NewMoDB(dataPath, logger)
 	}
 	a.modb.SetMaxEntryCount(maxLogResults)
+	scanDB, err := indextree.NewRocksDB("scanner", dataPath)
+	if err != nil {
+		panic(err)
+	}
+	a.scanDB = scanDB
 	return &a
 }
 
@@ -170,8 +197,112 @@ func (a *ChainLogDB) GetLatestBlockInfo() (height, timestamp int64, hash [32]byt
 	return
 }
 
+func (a *ChainLogDB) SetMainChainBlock(height int64, blk *MainChainBlock) {
+	bz := make([]byte, 0, 32*(1+len(blk.Txids)))
+	bz = append(bz, blk.Hash[:]...)
+	for _, txid := range blk.Txids {
+		bz = append(bz, txid[:]...)
+	}
+	a.scanDB.SetSync(mainChainBlockKey(height), bz)
+}
+
+func (a *ChainLogDB) GetMainChainBlock(height int64) *MainChainBlock {
+	bz := a.scanDB.Get(mainChainBlockKey(height))
+	if len(bz) < 32 || len(bz)%32 != 0 {
+		return nil
+	}
+	blk := &MainChainBlock{}
+	copy(blk.Hash[:], bz[:32])
+	for bz = bz[32:]; len(bz) > 0; bz = bz[32:] {
+		var txid [32]byte
+		copy(txid[:], bz[:32])
+		blk.Txids = append(blk.Txids, txid)
+	}
+	return blk
+}
+
+func (a *ChainLogDB) DeleteMainChainBlock(height int64) {
+	a.scanDB.DeleteSync(mainChainBlockKey(height))
+}
+
+func mainChainBlockKey(height int64) []byte {
+	var key [9]byte
+	key[0] = 'M'
+	binary.BigEndian.PutUint64(key[1:], uint64(height))
+	return key[:]
+}
+
+var scanCheckpointKey = []byte{'C'}
+
+func (a *ChainLogDB) SetScanCheckpoint(cp *ScanCheckpoint) {
+	var bz [40]byte
+	binary.BigEndian.PutUint64(bz[:8], uint64(cp.Height))
+	copy(bz[8:], cp.Hash[:])
+	a.scanDB.SetSync(scanCheckpointKey, bz[:])
+}
+
+// GetScanCheckpoint returns nil if no checkpoint is saved
+func (a *ChainLogDB) GetScanCheckpoint() *ScanCheckpoint {
+	bz := a.scanDB.Get(scanCheckpointKey)
+	if len(bz) != 40 {
+		return nil
+	}
+	cp := &ScanCheckpoint{Height: int64(binary.BigEndian.Uint64(bz[:8]))}
+	copy(cp.Hash[:], bz[8:])
+	return cp
+}
+
+// AddRejectedTx records a mempool tx which is not an EGTX until expireTime, so it is not fetched again after a restart.
+// It is not synced, losing it only costs a fetch.
+func (a *ChainLogDB) AddRejectedTx(txid [32]byte, expireTime int64) {
+	var expireBz [8]byte
+	binary.BigEndian.PutUint64(expireBz[:], uint64(expireTime))
+	a.scanDB.Set(rejectedTxKey(txid), expireBz[:])
+	a.scanDB.Set(rejectedTxExpireKey(expireTime, txid), []byte{})
+}
+
+func (a *ChainLogDB) IsTxRejected(txid [32]byte) bool {
+	return a.scanDB.Has(rejectedTxKey(txid))
+}
+
+// PruneRejectedTxs deletes the rejected txs expiring before now, and returns how many are deleted
+func (a *ChainLogDB) PruneRejectedTxs(now int64) int {
+	start := rejectedTxExpireKey(0, [32]byte{})
+	end := rejectedTxExpireKey(now, [32]byte{})
+	var keys [][]byte
+	iter := a.scanDB.Iterator(start, end)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, append([]byte{}, iter.Key()...))
+	}
+	iter.Close()
+	for _, key := range keys {
+		var txid [32]byte
+		copy(txid[:], key[9:])
+		// the tx may be rejected again with a later expire time
+		if bytes.Equal(a.scanDB.Get(rejectedTxKey(txid)), key[1:9]) {
+			a.scanDB.Delete(rejectedTxKey(txid))
+		}
+		a.scanDB.Delete(key)
+	}
+	return len(keys)
+}
+
+func rejectedTxKey(txid [32]byte) []byte {
+	return append([]byte{'R'}, txid[:]...)
+}
+
+// rejectedTxExpireKey indexes the rejected txs by expire time, for pruning
+func rejectedTxExpireKey(expireTime int64, txid [32]byte) []byte {
+	var key [41]byte
+	key[0] = 'E'
+	binary.BigEndian.PutUint64(key[1:9], uint64(expireTime))
+	copy(key[9:], txid[:])
+	return key[:]
+}
+
 func (a *ChainLogDB) Close() {
 	a.modb.Close()
+	a.scanDB.Close()
 }
 
 var _ IStore = &ChainLogDB{}
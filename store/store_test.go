@@ -51,3 +51,53 @@ func TestGetTx(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, tx1.Logs, 1)
 }
+
+func TestMainChainBlock(t *testing.T) {
+	db := NewChainLogDB(dbPath, 100, log.NewNopLogger())
+	defer os.RemoveAll(dbPath)
+	defer db.Close()
+
+	require.Nil(t, db.GetMainChainBlock(100))
+	db.SetMainChainBlock(100, &MainChainBlock{Hash: [32]byte{0xB1}})
+	db.SetMainChainBlock(101, &MainChainBlock{
+		Hash:  [32]byte{0xB2},
+		Txids: [][32]byte{{0xC1}, {0xC2}},
+	})
+
+	blk := db.GetMainChainBlock(100)
+	require.Equal(t, [32]byte{0xB1}, blk.Hash)
+	require.Len(t, blk.Txids, 0)
+	blk = db.GetMainChainBlock(101)
+	require.Equal(t, [32]byte{0xB2}, blk.Hash)
+	require.Equal(t, [][32]byte{{0xC1}, {0xC2}}, blk.Txids)
+
+	db.DeleteMainChainBlock(101)
+	require.Nil(t, db.GetMainChainBlock(101))
+}
+
+func TestScannerState(t *testing.T) {
+	db := NewChainLogDB(dbPath, 100, log.NewNopLogger())
+	defer os.RemoveAll(dbPath)
+	defer db.Close()
+
+	require.Nil(t, db.GetScanCheckpoint())
+	db.SetScanCheckpoint(&ScanCheckpoint{Height: 100, Hash: [32]byte{0x01}})
+	require.Equal(t, &ScanCheckpoint{Height: 100, Hash: [32]byte{0x01}}, db.GetScanCheckpoint())
+
+	db.AddRejectedTx([32]byte{0xA1}, 1000)
+	db.AddRejectedTx([32]byte{0xA2}, 2000)
+	require.True(t, db.IsTxRejected([32]byte{0xA1}))
+	require.True(t, db.IsTxRejected([32]byte{0xA2}))
+	require.False(t, db.IsTxRejected([32]byte{0xA3}))
+	require.Equal(t, 1, db.PruneRejectedTxs(1500))
+	require.False(t, db.IsTxRejected([32]byte{0xA1}))
+	require.True(t, db.IsTxRejected([32]byte{0xA2}))
+	require.Equal(t, 0, db.PruneRejectedTxs(1500))
+
+	// rejected again with a later expire time
+	db.AddRejectedTx([32]byte{0xA2}, 3000)
+	require.Equal(t, 1, db.PruneRejectedTxs(2500))
+	require.True(t, db.IsTxRejected([32]byte{0xA2}))
+	require.Equal(t, 1, db.PruneRejectedTxs(3500))
+	require.False(t, db.IsTxRejected([32]byte{0xA2}))
+}
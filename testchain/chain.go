@@ -28,7 +28,7 @@ func CreateTestChain() *TestChain {
 	_ = os.RemoveAll(dbPath)
 
 	cfg := config.DefaultConfig()
-	bchChainConfig := config.NewBchChainConfig(&cfg, []string{}, 0)
+	bchChainConfig := config.NewBchChainConfig(&cfg, config.MainNet, []string{}, 0)
 	cfg.RegisterChainConfig(bchChainConfig.ChainName, bchChainConfig)
 	defaultRpcEthGetLogsMaxResults := 10000
 	s := store.NewChainLogDB(dbPath, defaultRpcEthGetLogsMaxResults, log.NewNopLogger())
@@ -40,6 +40,7 @@ func CreateTestChain() *TestChain {
 		BlockInterval: 5,
 		ChainName:     bchChainConfig.ChainName,
 		ChainID:       bchChainConfig.ChainId,
+		EvmChainId:    bchChainConfig.EvmChainId,
 	}
 	bchVirtualChain.SetLogger(log.NewNopLogger())
 	a.RegisterChain(bchChainConfig.ChainName, bchVirtualChain)
@@ -68,6 +69,10 @@ func (tc *TestChain) AddTx(txHash gethcmn.Hash, logs ...mevmtypes.Log) {
 	})
 }
 
+func (tc *TestChain) RemoveTx(txHash gethcmn.Hash) {
+	tc.scanner.removedTxs = append(tc.scanner.removedTxs, txHash)
+}
+
 func (tc *TestChain) GenNewBlock() (h int64, hash gethcmn.Hash) {
 	tc.GenerateNewBlock(true)
 	tc.Store.AddBlock(nil)
@@ -1,17 +1,22 @@
 package testchain
 
 import (
+	"errors"
+
 	mdbtypes "github.com/smartbch/moeingdb/types"
 	mevmtypes "github.com/smartbch/moeingevm/types"
 
+	"github.com/elfinguard/chainlogs/bch"
 	"github.com/elfinguard/chainlogs/scanner"
 	"github.com/elfinguard/chainlogs/testutils"
+	"github.com/elfinguard/chainlogs/types"
 )
 
 var _ scanner.IScanner = (*FakeScanner)(nil)
 
 type FakeScanner struct {
-	newTxs []mevmtypes.Transaction
+	newTxs     []mevmtypes.Transaction
+	removedTxs [][32]byte
 }
 
 func (s *FakeScanner) SetLatestScanHeight(blockHeight int64) {
@@ -22,7 +27,7 @@ func (s *FakeScanner) GetLatestScanHeight() int64 {
 	return 0
 }
 
-func (s *FakeScanner) GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock bool) []mdbtypes.Tx {
+func (s *FakeScanner) GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock bool) ([]mdbtypes.Tx, error) {
 	newTxs := s.newTxs
 	s.newTxs = nil
 
@@ -41,10 +46,56 @@ func (s *FakeScanner) GetNewTxs(blockHeight int64, blockHash [32]byte, scanBlock
 		}
 	}
 
-	return mdbTxs
+	return mdbTxs, nil
+}
+
+func (s *FakeScanner) GetBlockTxs(blockHeight int64, blockHash [32]byte, toHeight int64) ([]mdbtypes.Tx, error) {
+	return s.GetNewTxs(blockHeight, blockHash, true)
+}
+
+func (s *FakeScanner) GetMainChainBlockTxs(blockHeight, minConfirmations int64, deriveHash func(mainChainBlockHash [32]byte) [32]byte) (*scanner.MainChainBlockTxs, error) {
+	return nil, nil
 }
 
 func (s *FakeScanner) GetConfirmations(txHash [32]byte) int32 {
 	// TODO
 	return 0
 }
+
+func (s *FakeScanner) MainChainEndpoints() []bch.EndpointStatus {
+	return nil
+}
+
+func (s *FakeScanner) GetQuarantinedTxs() []types.QuarantinedTx {
+	return nil
+}
+
+func (s *FakeScanner) GetDroppedTxs() []types.DroppedTx {
+	return nil
+}
+
+func (s *FakeScanner) GetSkippedBlocks() []types.SkippedBlock {
+	return nil
+}
+
+func (s *FakeScanner) PrevoutStats() types.PrevoutStats {
+	return types.PrevoutStats{}
+}
+
+func (s *FakeScanner) GetTxProof(txHash [32]byte, fromHeight int64, count int) (*types.TxProof, error) {
+	return nil, types.TxNotConfirmed
+}
+
+func (s *FakeScanner) GetSourceTx(txHash [32]byte) (*types.SourceTx, error) {
+	return nil, errors.New("source tx not found")
+}
+
+func (s *FakeScanner) GetScanStatus() (types.ScanStatus, error) {
+	return types.ScanStatus{}, nil
+}
+
+func (s *FakeScanner) CollectRemovedTxs() [][32]byte {
+	removedTxs := s.removedTxs
+	s.removedTxs = nil
+	return removedTxs
+}
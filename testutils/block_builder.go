@@ -2,10 +2,11 @@ package testutils
 
 import (
 	gethcmn "github.com/ethereum/go-ethereum/common"
-	gethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	modbtypes "github.com/smartbch/moeingdb/types"
 	"github.com/smartbch/moeingevm/types"
+
+	chainlogstypes "github.com/elfinguard/chainlogs/types"
 )
 
 type MdbBlockBuilder struct {
@@ -89,7 +90,7 @@ func (bb *MdbBlockBuilder) Build() *modbtypes.Block {
 		//copy(mdbTxList[i].DstAddr[:], tx.To[:])
 	}
 
-	bb.block.LogsBloom = createBloom(mdbTxList)
+	bb.block.LogsBloom = chainlogstypes.CreateBloom(mdbTxList)
 	blockInfo, _ := bb.block.MarshalMsg(nil)
 
 	return &modbtypes.Block{
@@ -110,16 +111,3 @@ func ToMdbLogs(logs []types.Log) []modbtypes.Log {
 	}
 	return mdbLogs
 }
-
-func createBloom(txList []modbtypes.Tx) gethtypes.Bloom {
-	var bin gethtypes.Bloom
-	for _, tx := range txList {
-		for _, log := range tx.LogList {
-			bin.Add(log.Address[:])
-			for _, b := range log.Topics {
-				bin.Add(b[:])
-			}
-		}
-	}
-	return bin
-}
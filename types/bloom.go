@@ -0,0 +1,20 @@
+package types
+
+import (
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	modbtypes "github.com/smartbch/moeingdb/types"
+)
+
+// CreateBloom returns the bloom filter of the addresses and topics of the txs' logs
+func CreateBloom(txList []modbtypes.Tx) gethtypes.Bloom {
+	var bin gethtypes.Bloom
+	for _, tx := range txList {
+		for _, log := range tx.LogList {
+			bin.Add(log.Address[:])
+			for _, b := range log.Topics {
+				bin.Add(b[:])
+			}
+		}
+	}
+	return bin
+}
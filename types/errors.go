@@ -1,6 +1,9 @@
 package types
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	FirstOutputMustEGTX           = errors.New("first output must EGTX typed nulldata")
@@ -8,4 +11,59 @@ var (
 	NotHaveEGTXNulldata           = errors.New("not have EGTX typed nulldata")
 	NotHaveContractAddress        = errors.New("not have contract address")
 	PubkeyScriptAddressNumInvalid = errors.New("invalid pubkey script address num")
+	TxNotConfirmed                = errors.New("tx is not confirmed in a main chain block")
 )
+
+// NodeError means the main chain node fails, the scan should be retried later
+type NodeError struct {
+	Method string
+	Err    error
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("main chain node %s failed: %s", e.Method, e.Err)
+}
+
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
+
+// ReorgTooDeepError means the main chain reorganizes a block whose aligned virtual block is built, it can not be
+// replaced, so the aligned block production halts
+type ReorgTooDeepError struct {
+	Height           int64
+	MinConfirmations int64
+}
+
+func (e *ReorgTooDeepError) Error() string {
+	return fmt.Sprintf("main chain reorg at height %d is deeper than %d confirmations", e.Height, e.MinConfirmations)
+}
+
+// TxError means an EGTX can not be derived, such as its address is undecodable, so it is quarantined
+type TxError struct {
+	Txid string
+	Err  error
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("invalid tx %s: %s", e.Txid, e.Err)
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// QuarantinedTx is an EGTX skipped by the scanner because of a TxError
+type QuarantinedTx struct {
+	Txid   string `json:"txid"`
+	Reason string `json:"reason"`
+	Time   int64  `json:"time"`
+}
+
+// ScannerHealth is unhealthy when the last scan failed, such as the main chain node is down
+type ScannerHealth struct {
+	Healthy  bool   `json:"healthy"`
+	Starting bool   `json:"starting,omitempty"` // no scan has finished yet
+	Error    string `json:"error,omitempty"`
+	Since    int64  `json:"since,omitempty"` // unix time of the first failure
+}
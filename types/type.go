@@ -1 +1,75 @@
 package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gcash/bchd/btcjson"
+)
+
+// PrevoutStats counts how the outputs spent by EGTXs are resolved
+type PrevoutStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	TxOuts uint64 `json:"txOuts"` // misses resolved by gettxout
+	RawTxs uint64 `json:"rawTxs"` // getrawtransaction calls for the other misses
+}
+
+// TxProof proves that a main chain tx is in a block, which is followed by Headers
+type TxProof struct {
+	Txid         string   `json:"txid"`
+	RawTx        string   `json:"rawTx"`
+	BlockHash    string   `json:"blockHash"`
+	BlockHeight  int64    `json:"blockHeight"`
+	BlockHeader  string   `json:"blockHeader"` // the serialized 80-byte header
+	TxIndex      int      `json:"txIndex"`
+	MerkleBranch []string `json:"merkleBranch"` // the siblings from the tx up to the merkle root, hex in the RPC byte order
+	Headers      []string `json:"headers"`      // the serialized headers of the following blocks from the requested height
+	TipHeight    int64    `json:"tipHeight"`    // the main chain tip, the headers after the last one are in the next page
+}
+
+// DroppedTx is an unconfirmed EGTX which left the mempool without being mined
+type DroppedTx struct {
+	Txid   string `json:"txid"`
+	Reason string `json:"reason"` // "double-spent" if an input is spent by another tx, otherwise "evicted"
+	Time   int64  `json:"time"`
+}
+
+// SourceTx is the main chain tx of an EGTX with its decoded inputs and outputs
+type SourceTx struct {
+	Txid          string         `json:"txid"`
+	Hex           string         `json:"hex"`
+	BlockHash     string         `json:"blockHash,omitempty"` // empty if the tx is in the mempool
+	Confirmations uint64         `json:"confirmations"`
+	Inputs        []SourceInput  `json:"inputs"`
+	Outputs       []SourceOutput `json:"outputs"`
+}
+
+type SourceInput struct {
+	Txid    string        `json:"txid"`
+	Vout    uint32        `json:"vout"`
+	Prevout *SourceOutput `json:"prevout"` // the spent output, nil for coinbase inputs
+}
+
+type SourceOutput struct {
+	Value      int64                    `json:"value"` // in satoshis
+	ScriptType string                   `json:"scriptType"`
+	Script     string                   `json:"script"`
+	Address    *common.Address          `json:"address,omitempty"` // the 20-byte address put into EGTX logs
+	TokenData  *btcjson.TokenDataResult `json:"tokenData,omitempty"`
+}
+
+// ScanStatus tells how far the scanner is behind the main chain node
+type ScanStatus struct {
+	ScanHeight   int64 `json:"scanHeight"` // the latest scanned main chain block
+	NodeTip      int64 `json:"nodeTip"`
+	Lag          int64 `json:"lag"`
+	LatestHeight int64 `json:"latestHeight"` // the latest virtual block
+}
+
+// SkippedBlock is a main chain block skipped because the main chain nodes do not reach quorum on it,
+// the EGTXs in it are not collected
+type SkippedBlock struct {
+	Height int64  `json:"height"`
+	Hash   string `json:"hash"`
+	Reason string `json:"reason"`
+	Time   int64  `json:"time"`
+}
@@ -0,0 +1,116 @@
+package zmq
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"sync"
+)
+
+// Publisher is a minimal ZMQ PUB socket, it stands in for the main chain node's notifications in tests
+type Publisher struct {
+	ln net.Listener
+
+	mtx  sync.Mutex
+	subs map[net.Conn][][]byte // the topics subscribed by each connection
+}
+
+// NewPublisher listens on addr, such as 127.0.0.1:0
+func NewPublisher(addr string) (*Publisher, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	p := &Publisher{ln: ln, subs: make(map[net.Conn][][]byte)}
+	go p.acceptLoop()
+	return p, nil
+}
+
+func (p *Publisher) Addr() string {
+	return "tcp://" + p.ln.Addr().String()
+}
+
+func (p *Publisher) acceptLoop() {
+	for {
+		conn, err := p.ln.Accept()
+		if err != nil {
+			return
+		}
+		go p.serve(conn)
+	}
+}
+
+func (p *Publisher) serve(conn net.Conn) {
+	defer p.drop(conn)
+	if _, err := handshake(conn, "PUB"); err != nil {
+		return
+	}
+	p.mtx.Lock()
+	p.subs[conn] = nil
+	p.mtx.Unlock()
+	for {
+		flags, body, err := readFrame(conn)
+		if err != nil {
+			return
+		}
+		if flags&flagCommand != 0 || len(body) == 0 || body[0] != 1 {
+			continue // unsubscribing is not supported
+		}
+		p.mtx.Lock()
+		p.subs[conn] = append(p.subs[conn], body[1:])
+		p.mtx.Unlock()
+	}
+}
+
+func (p *Publisher) drop(conn net.Conn) {
+	p.mtx.Lock()
+	delete(p.subs, conn)
+	p.mtx.Unlock()
+	_ = conn.Close()
+}
+
+// Subscribed returns whether any connection subscribes to the topic
+func (p *Publisher) Subscribed(topic string) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, topics := range p.subs {
+		for _, t := range topics {
+			if bytes.HasPrefix([]byte(topic), t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Publish sends the message to the subscribers in the same format as bitcoind
+func (p *Publisher) Publish(topic string, body []byte, seq uint32) {
+	var seqBz [4]byte
+	binary.LittleEndian.PutUint32(seqBz[:], seq)
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for conn, topics := range p.subs {
+		for _, t := range topics {
+			if bytes.HasPrefix([]byte(topic), t) {
+				_ = writeFrame(conn, flagMore, []byte(topic))
+				_ = writeFrame(conn, flagMore, body)
+				_ = writeFrame(conn, 0, seqBz[:])
+				break
+			}
+		}
+	}
+}
+
+// DisconnectAll closes the subscribers' connections, like a restarted node
+func (p *Publisher) DisconnectAll() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for conn := range p.subs {
+		_ = conn.Close()
+	}
+}
+
+func (p *Publisher) Close() error {
+	p.DisconnectAll()
+	return p.ln.Close()
+}
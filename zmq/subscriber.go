@@ -0,0 +1,74 @@
+package zmq
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Message is a notification of bitcoind-like nodes, which has three frames: topic, body and sequence number
+type Message struct {
+	Topic string
+	Body  []byte
+	Seq   uint32
+}
+
+// Subscriber is a ZMQ SUB socket connected to one publisher
+type Subscriber struct {
+	conn net.Conn
+}
+
+// Subscribe connects to addr, such as tcp://127.0.0.1:28332, and subscribes to the topics
+func Subscribe(addr string, dialTimeout time.Duration, topics ...string) (*Subscriber, error) {
+	conn, err := net.DialTimeout("tcp", strings.TrimPrefix(addr, "tcp://"), dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	peerType, err := handshake(conn, "SUB")
+	if err == nil && peerType != "PUB" && peerType != "XPUB" {
+		err = fmt.Errorf("unexpected zmq socket type: %s", peerType)
+	}
+	for _, topic := range topics {
+		if err != nil {
+			break
+		}
+		err = writeFrame(conn, 0, append([]byte{1}, topic...))
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &Subscriber{conn: conn}, nil
+}
+
+// Receive blocks until a message is received or the connection is broken
+func (s *Subscriber) Receive() (*Message, error) {
+	var frames [][]byte
+	for {
+		flags, body, err := readFrame(s.conn)
+		if err != nil {
+			return nil, err
+		}
+		if flags&flagCommand != 0 {
+			continue
+		}
+		frames = append(frames, body)
+		if flags&flagMore == 0 {
+			break
+		}
+	}
+	msg := &Message{Topic: string(frames[0])}
+	if len(frames) > 1 {
+		msg.Body = frames[1]
+	}
+	if len(frames) > 2 && len(frames[2]) == 4 {
+		msg.Seq = binary.LittleEndian.Uint32(frames[2])
+	}
+	return msg, nil
+}
+
+func (s *Subscriber) Close() error {
+	return s.conn.Close()
+}
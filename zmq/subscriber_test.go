@@ -0,0 +1,36 @@
+package zmq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe(t *testing.T) {
+	p, err := NewPublisher("127.0.0.1:0")
+	require.NoError(t, err)
+	defer p.Close()
+
+	s, err := Subscribe(p.Addr(), time.Second, "rawtx", "hashblock")
+	require.NoError(t, err)
+	defer s.Close()
+	require.Eventually(t, func() bool { return p.Subscribed("hashblock") }, time.Second, 10*time.Millisecond)
+
+	p.Publish("sequence", []byte{1}, 0) // not subscribed
+	p.Publish("rawtx", []byte{1, 2, 3}, 7)
+	longBody := make([]byte, 1000)
+	longBody[999] = 9
+	p.Publish("hashblock", longBody, 8)
+
+	msg, err := s.Receive()
+	require.NoError(t, err)
+	require.Equal(t, &Message{Topic: "rawtx", Body: []byte{1, 2, 3}, Seq: 7}, msg)
+	msg, err = s.Receive()
+	require.NoError(t, err)
+	require.Equal(t, &Message{Topic: "hashblock", Body: longBody, Seq: 8}, msg)
+
+	p.DisconnectAll()
+	_, err = s.Receive()
+	require.Error(t, err)
+}
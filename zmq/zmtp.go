@@ -0,0 +1,133 @@
+// Package zmq implements the minimal part of ZMTP 3.0 (NULL mechanism) needed to subscribe to
+// the notifications published by bitcoind-like nodes, such as zmqpubrawtx and zmqpubhashblock.
+package zmq
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	flagMore    = 0x01
+	flagLong    = 0x02
+	flagCommand = 0x04
+
+	maxFrameSize = 64 << 20
+)
+
+var ErrInvalidGreeting = errors.New("invalid zmtp greeting")
+
+func greeting() []byte {
+	g := make([]byte, 64)
+	g[0] = 0xff
+	g[9] = 0x7f
+	g[10] = 3 // version 3.0
+	copy(g[12:], "NULL")
+	return g
+}
+
+func readGreeting(r io.Reader) error {
+	var g [64]byte
+	if _, err := io.ReadFull(r, g[:]); err != nil {
+		return err
+	}
+	if g[0] != 0xff || g[9] != 0x7f || g[10] < 3 || !bytes.Equal(g[12:16], []byte("NULL")) {
+		return ErrInvalidGreeting
+	}
+	return nil
+}
+
+func writeFrame(w io.Writer, flags byte, body []byte) error {
+	var header []byte
+	if len(body) > 255 {
+		header = make([]byte, 9)
+		header[0] = flags | flagLong
+		binary.BigEndian.PutUint64(header[1:], uint64(len(body)))
+	} else {
+		header = []byte{flags, byte(len(body))}
+	}
+	if _, err := w.Write(append(header, body...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readFrame(r io.Reader) (flags byte, body []byte, err error) {
+	var header [9]byte
+	if _, err = io.ReadFull(r, header[:2]); err != nil {
+		return
+	}
+	flags = header[0]
+	size := uint64(header[1])
+	if flags&flagLong != 0 {
+		if _, err = io.ReadFull(r, header[2:]); err != nil {
+			return
+		}
+		size = binary.BigEndian.Uint64(header[1:])
+	}
+	if size > maxFrameSize {
+		err = fmt.Errorf("zmtp frame too large: %d", size)
+		return
+	}
+	body = make([]byte, size)
+	_, err = io.ReadFull(r, body)
+	return
+}
+
+// readyCommand returns the body of the READY command with the Socket-Type property
+func readyCommand(socketType string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(5)
+	buf.WriteString("READY")
+	buf.WriteByte(byte(len("Socket-Type")))
+	buf.WriteString("Socket-Type")
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(socketType)))
+	buf.Write(size[:])
+	buf.WriteString(socketType)
+	return buf.Bytes()
+}
+
+// handshake exchanges the greetings and READY commands, and returns the peer's Socket-Type
+func handshake(rw io.ReadWriter, socketType string) (string, error) {
+	if _, err := rw.Write(greeting()); err != nil {
+		return "", err
+	}
+	if err := readGreeting(rw); err != nil {
+		return "", err
+	}
+	if err := writeFrame(rw, flagCommand, readyCommand(socketType)); err != nil {
+		return "", err
+	}
+	flags, body, err := readFrame(rw)
+	if err != nil {
+		return "", err
+	}
+	if flags&flagCommand == 0 || len(body) < 6 || string(body[1:6]) != "READY" {
+		return "", errors.New("zmtp READY command expected")
+	}
+	return parseSocketType(body[6:])
+}
+
+func parseSocketType(props []byte) (string, error) {
+	for len(props) > 0 {
+		nameLen := int(props[0])
+		if len(props) < 1+nameLen+4 {
+			break
+		}
+		name := string(props[1 : 1+nameLen])
+		valueLen := int(binary.BigEndian.Uint32(props[1+nameLen:]))
+		props = props[1+nameLen+4:]
+		if len(props) < valueLen {
+			break
+		}
+		if name == "Socket-Type" {
+			return string(props[:valueLen]), nil
+		}
+		props = props[valueLen:]
+	}
+	return "", errors.New("invalid zmtp metadata")
+}